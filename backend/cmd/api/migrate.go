@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"task-management/internal/config"
+	"task-management/internal/infra/db"
+)
+
+const migrateUsage = `usage: migrate <command>
+
+commands:
+  up [n]         run all pending migrations, or only the next n
+  down [n]       roll back the last n applied migrations (default 1)
+  status         list migrations and when they were applied
+  create <name>  create empty up and down scripts for every database driver`
+
+// runMigrate menjalankan subcommand migrate dan mengembalikan exit code.
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	command, rest := args[0], args[1:]
+
+	if command == "create" {
+		if len(rest) != 1 {
+			fmt.Fprintln(os.Stderr, "usage: migrate create <name>")
+			return 2
+		}
+
+		files, err := db.CreateMigration(db.MigrationsDir, rest[0], time.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "failed to create migration:", err)
+			return 1
+		}
+
+		for _, file := range files {
+			fmt.Println("created", file)
+		}
+
+		return 0
+	}
+
+	if command != "up" && command != "down" && command != "status" {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	steps := 0
+	if command == "down" {
+		steps = 1
+	}
+
+	if len(rest) > 0 && command != "status" {
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n < 1 {
+			fmt.Fprintln(os.Stderr, "number of migrations must be a positive integer")
+			return 2
+		}
+
+		steps = n
+	}
+
+	loadConfig()
+
+	database, err := db.Connect(config.Config.Database)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer database.Close()
+
+	migrator, err := db.NewMigrator(database.DB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(steps)
+		for _, migration := range applied {
+			fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		reverted, err := migrator.Down(steps)
+		for _, migration := range reverted {
+			fmt.Printf("rolled back %d_%s\n", migration.Version, migration.Name)
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Printf("%d_%s\t%s\n", status.Version, status.Name, applied)
+		}
+	}
+
+	return 0
+}
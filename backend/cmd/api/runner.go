@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	_ "time/tzdata"
 
 	_ "task-management/cmd/api/docs"
 	"task-management/internal/config"
@@ -17,19 +18,12 @@ import (
 // @host localhost:3010
 // @BasePath /api/v1
 func main() {
-	configPath, err := filepath.Abs("config")
-
-	if err != nil {
-		println("Gagal mendapatkan path absolute:", err.Error())
-		os.Exit(1)
+	// subcommand migrate: go run ./cmd/api migrate <up|down|status|create>
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(os.Args[2:]))
 	}
 
-	// Load configuration
-	if err := config.LoadConfig(configPath); err != nil {
-		println(configPath)
-		println("Failed to load configuration file:", err.Error())
-		os.Exit(1)
-	}
+	loadConfig()
 
 	// load jwt secret
 	jwtSecret := config.Config.Secret
@@ -47,10 +41,32 @@ func main() {
 	defer database.Close()
 
 	// init app server
-	initApp := server.InitServer(&config.Config, database.DB)
+	initApp, err := server.InitServer(&config.Config, database.DB)
+
+	if err != nil {
+		log.Fatalf("server setup failed: %v", err)
+	}
 
 	app := server.StartServer(initApp)
-	server.WaitForShutdown(app, func() {
+	initApp.Scheduler.Start()
+
+	server.WaitForShutdown(app, initApp.Scheduler.Stop, func() {
 		_ = database.Close()
 	})
 }
+
+func loadConfig() {
+	configPath, err := filepath.Abs("config")
+
+	if err != nil {
+		println("Gagal mendapatkan path absolute:", err.Error())
+		os.Exit(1)
+	}
+
+	// Load configuration
+	if err := config.LoadConfig(configPath); err != nil {
+		println(configPath)
+		println("Failed to load configuration file:", err.Error())
+		os.Exit(1)
+	}
+}
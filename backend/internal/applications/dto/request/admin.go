@@ -0,0 +1,12 @@
+package request
+
+type ListUsers struct {
+	Query string `form:"q"`
+	Page  int    `form:"page" binding:"omitempty,min=1"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type AdminResetPassword struct {
+	// kosong berarti password sementara dibuat oleh server
+	NewPassword string `json:"new_password"`
+}
@@ -0,0 +1,9 @@
+package request
+
+import "time"
+
+type CreateAPIToken struct {
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1,dive,oneof=tasks:read tasks:write profile admin"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
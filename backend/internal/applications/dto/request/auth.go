@@ -3,6 +3,7 @@ package request
 type RegisterUser struct {
 	Name     string `json:"name" binding:"required"`
 	Username string `json:"username" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email"`
 	Password string `json:"password" binding:"required"`
 }
 
@@ -10,3 +11,56 @@ type LoginUser struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
+
+type LoginTwoFactor struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorCode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// DisableTwoFactor, password wajib untuk akun yang punya password (bukan akun SSO).
+type DisableTwoFactor struct {
+	Password string `json:"password"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ForgotPassword struct {
+	Username string `json:"username" binding:"required"`
+}
+
+type ResetPassword struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type SetupAdmin struct {
+	Name     string `json:"name" binding:"required"`
+	Username string `json:"username" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+// UpdateProfile, field yang tidak dikirim tidak diubah. Email kosong menghapus email.
+type UpdateProfile struct {
+	Name     *string `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Email    *string `json:"email,omitempty" binding:"omitempty,max=255"`
+	Timezone *string `json:"timezone,omitempty" binding:"omitempty,max=64"`
+	Locale   *string `json:"locale,omitempty" binding:"omitempty,max=16"`
+}
+
+type VerifyEmail struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// DeleteAccount, password wajib untuk akun yang punya password (bukan akun SSO).
+type DeleteAccount struct {
+	Password string `json:"password"`
+}
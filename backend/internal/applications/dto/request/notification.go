@@ -0,0 +1,16 @@
+package request
+
+import "task-management/internal/domain"
+
+type ListNotifications struct {
+	Unread bool `form:"unread"`
+	Page   int  `form:"page" binding:"omitempty,min=1"`
+	Limit  int  `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+type UpdateNotificationPreferences struct {
+	EmailEnabled     *bool                   `json:"email_enabled,omitempty"`
+	TaskAssigned     *bool                   `json:"task_assigned,omitempty"`
+	DeadlineReminder *bool                   `json:"deadline_reminder,omitempty"`
+	Digest           *domain.DigestFrequency `json:"digest,omitempty" binding:"omitempty,oneof=none daily weekly"`
+}
@@ -6,14 +6,14 @@ import (
 )
 
 type CreateTask struct {
-	Title       string            `json:"title" binding:"required"`
+	Title       string            `json:"title" binding:"required,max=255"`
 	Description string            `json:"description" binding:"required"`
 	Status      domain.TaskStatus `json:"status" binding:"required,oneof='To Do' 'In Progress' 'Done'"`
 	Deadline    *time.Time        `json:"deadline,omitempty"`
 }
 
 type UpdateTask struct {
-	Title       *string            `json:"title,omitempty"`
+	Title       *string            `json:"title,omitempty" binding:"omitempty,max=255"`
 	Description *string            `json:"description,omitempty"`
 	Status      *domain.TaskStatus `json:"status,omitempty" binding:"omitempty"`
 	Deadline    *time.Time         `json:"deadline,omitempty"`
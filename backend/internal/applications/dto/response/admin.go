@@ -0,0 +1,45 @@
+package response
+
+import "time"
+
+type AdminUser struct {
+	ID               uint       `json:"id"`
+	Name             string     `json:"name"`
+	Username         string     `json:"username"`
+	Email            string     `json:"email,omitempty"`
+	Role             string     `json:"role"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	Active           bool       `json:"active"`
+	DeactivatedAt    *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	Tasks            TaskCounts `json:"tasks"`
+}
+
+type TaskCounts struct {
+	Total    int64            `json:"total"`
+	ByStatus map[string]int64 `json:"by_status,omitempty"`
+}
+
+type BaseAdminUserResponse struct {
+	Success bool      `json:"success"`
+	Code    int       `json:"code"`
+	Data    AdminUser `json:"data"`
+}
+
+type ListAdminUserResponse struct {
+	Success bool        `json:"success"`
+	Code    int         `json:"code"`
+	Data    []AdminUser `json:"data"`
+	Meta    Pagination  `json:"meta"`
+}
+
+type AdminResetPassword struct {
+	// hanya diisi jika password sementara dibuat oleh server
+	TemporaryPassword string `json:"temporary_password,omitempty"`
+}
+
+type BaseAdminResetPasswordResponse struct {
+	Success bool               `json:"success"`
+	Code    int                `json:"code"`
+	Data    AdminResetPassword `json:"data"`
+}
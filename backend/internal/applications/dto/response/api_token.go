@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+type APIToken struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatedAPIToken struct {
+	Token string   `json:"token"`
+	Info  APIToken `json:"info"`
+}
+
+type BaseCreatedAPITokenResponse struct {
+	Success bool            `json:"success"`
+	Code    int             `json:"code"`
+	Data    CreatedAPIToken `json:"data"`
+}
+
+type ListAPITokenResponse struct {
+	Success bool       `json:"success"`
+	Code    int        `json:"code"`
+	Data    []APIToken `json:"data"`
+}
@@ -1,8 +1,11 @@
 package response
 
+// AuthResponse berisi access token, atau challenge token jika TwoFactorRequired.
 type AuthResponse struct {
-	Token string        `json:"token"`
-	User  *UserResponse `json:"user"`
+	Token             string        `json:"token,omitempty"`
+	User              *UserResponse `json:"user,omitempty"`
+	TwoFactorRequired bool          `json:"two_factor_required,omitempty"`
+	ChallengeToken    string        `json:"challenge_token,omitempty"`
 }
 
 type BaseAuthResponse struct {
@@ -10,3 +13,24 @@ type BaseAuthResponse struct {
 	Code    int          `json:"code"`
 	Data    AuthResponse `json:"data"`
 }
+
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type BaseTwoFactorEnrollmentResponse struct {
+	Success bool                `json:"success"`
+	Code    int                 `json:"code"`
+	Data    TwoFactorEnrollment `json:"data"`
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type BaseRecoveryCodesResponse struct {
+	Success bool          `json:"success"`
+	Code    int           `json:"code"`
+	Data    RecoveryCodes `json:"data"`
+}
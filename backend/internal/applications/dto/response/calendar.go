@@ -0,0 +1,12 @@
+package response
+
+type CalendarFeed struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+type BaseCalendarFeedResponse struct {
+	Success bool         `json:"success"`
+	Code    int          `json:"code"`
+	Data    CalendarFeed `json:"data"`
+}
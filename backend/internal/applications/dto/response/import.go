@@ -0,0 +1,43 @@
+package response
+
+type ImportRowError struct {
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+type ImportResult struct {
+	DryRun   bool             `json:"dry_run"`
+	Total    int              `json:"total"`
+	Valid    int              `json:"valid"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+type BaseImportResponse struct {
+	Success bool         `json:"success"`
+	Code    int          `json:"code"`
+	Data    ImportResult `json:"data"`
+}
+
+type ExternalImportError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+type ExternalImportResult struct {
+	Source  string                `json:"source"`
+	DryRun  bool                  `json:"dry_run"`
+	Total   int                   `json:"total"`
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Failed  int                   `json:"failed"`
+	Errors  []ExternalImportError `json:"errors"`
+}
+
+type BaseExternalImportResponse struct {
+	Success bool                 `json:"success"`
+	Code    int                  `json:"code"`
+	Data    ExternalImportResult `json:"data"`
+}
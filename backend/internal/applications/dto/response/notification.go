@@ -0,0 +1,45 @@
+package response
+
+import "time"
+
+type Notification struct {
+	ID        uint       `json:"id"`
+	TaskID    *uint      `json:"task_id,omitempty"`
+	Type      string     `json:"type"`
+	Message   string     `json:"message"`
+	Read      bool       `json:"read"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type Pagination struct {
+	Page  int   `json:"page"`
+	Limit int   `json:"limit"`
+	Total int64 `json:"total"`
+}
+
+type ListNotificationResponse struct {
+	Success bool           `json:"success"`
+	Code    int            `json:"code"`
+	Data    []Notification `json:"data"`
+	Meta    Pagination     `json:"meta"`
+}
+
+type MessageResponse struct {
+	Success bool   `json:"success"`
+	Code    int    `json:"code"`
+	Data    string `json:"data"`
+}
+
+type NotificationPreference struct {
+	EmailEnabled     bool   `json:"email_enabled"`
+	TaskAssigned     bool   `json:"task_assigned"`
+	DeadlineReminder bool   `json:"deadline_reminder"`
+	Digest           string `json:"digest"`
+}
+
+type BaseNotificationPreferenceResponse struct {
+	Success bool                   `json:"success"`
+	Code    int                    `json:"code"`
+	Data    NotificationPreference `json:"data"`
+}
@@ -0,0 +1,19 @@
+package response
+
+import "time"
+
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type ListSessionResponse struct {
+	Success bool      `json:"success"`
+	Code    int       `json:"code"`
+	Data    []Session `json:"data"`
+}
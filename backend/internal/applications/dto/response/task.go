@@ -8,6 +8,7 @@ type Task struct {
 	Description string     `json:"description"`
 	Status      string     `json:"status"`
 	Deadline    *time.Time `json:"deadline,omitempty"`
+	Labels      []string   `json:"labels,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 }
 
@@ -1,9 +1,28 @@
 package response
 
 type UserResponse struct {
-	ID       uint   `json:"id"`
-	Name     string `json:"name"`
-	Username string `json:"username"`
+	ID               uint   `json:"id"`
+	Name             string `json:"name"`
+	Username         string `json:"username"`
+	Email            string `json:"email,omitempty"`
+	EmailVerified    bool   `json:"email_verified"`
+	Timezone         string `json:"timezone,omitempty"`
+	Locale           string `json:"locale,omitempty"`
+	AvatarURL        string `json:"avatar_url,omitempty"`
+	Role             string `json:"role,omitempty"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
+}
+
+// ProfileResponse, PendingEmail berisi email baru yang menunggu verifikasi.
+type ProfileResponse struct {
+	User         UserResponse `json:"user"`
+	PendingEmail string       `json:"pending_email,omitempty"`
+}
+
+type BaseProfileResponse struct {
+	Success bool            `json:"success"`
+	Code    int             `json:"code"`
+	Data    ProfileResponse `json:"data"`
 }
 
 type BaseUserResponse struct {
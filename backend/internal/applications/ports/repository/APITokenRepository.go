@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type APITokenRepository interface {
+	Create(token *domain.APIToken) error
+	FindByID(id uint) (*domain.APIToken, error)
+	FindByHash(tokenHash string) (*domain.APIToken, error)
+	ListByUser(userID uint) ([]domain.APIToken, error)
+	Revoke(id uint, at time.Time) error
+	TouchLastUsed(id uint, at time.Time) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "task-management/internal/domain"
+
+type CalendarFeedRepository interface {
+	FindByUser(userID uint) (*domain.CalendarFeed, error)
+	FindByTokenHash(tokenHash string) (*domain.CalendarFeed, error)
+	Save(feed *domain.CalendarFeed) error
+	DeleteByUser(userID uint) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "task-management/internal/domain"
+
+type EmailVerificationTokenRepository interface {
+	Create(token *domain.EmailVerificationToken) error
+	// Take mengambil lalu menghapus token, nil jika tidak ada atau sudah diambil request lain.
+	Take(tokenHash string) (*domain.EmailVerificationToken, error)
+	DeleteByUser(userID uint) error
+}
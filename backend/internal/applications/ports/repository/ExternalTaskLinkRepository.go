@@ -0,0 +1,8 @@
+package repository
+
+import "task-management/internal/domain"
+
+type ExternalTaskLinkRepository interface {
+	Find(importedBy uint, source, externalID string) (*domain.ExternalTaskLink, error)
+	Save(link *domain.ExternalTaskLink) error
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+// LoginAttemptStore menyimpan counter login gagal. Tersedia implementasi in-memory dan DB.
+type LoginAttemptStore interface {
+	Get(key string) (*domain.LoginAttempt, error)
+	// Increment menambah Failures secara atomik dan mengembalikan hasilnya. Counter dimulai ulang
+	// dari 1 (dan kunci dilepas) jika kegagalan pertama lebih lama dari window.
+	Increment(key string, now time.Time, window time.Duration) (*domain.LoginAttempt, error)
+	// Decrement mengurangi Failures secara atomik, tidak pernah di bawah 0.
+	Decrement(key string) error
+	// Lock mengunci key sampai until.
+	Lock(key string, until time.Time) error
+	Delete(key string) error
+	Prune(before time.Time) error
+}
+
+type LoginAuditRepository interface {
+	Create(audit *domain.LoginAudit) error
+	// ListByUsername dan DeleteByUsername tidak membedakan huruf besar kecil seperti login.
+	ListByUsername(username string) ([]domain.LoginAudit, error)
+	DeleteByUsername(username string) error
+}
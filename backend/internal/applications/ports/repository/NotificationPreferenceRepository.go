@@ -0,0 +1,9 @@
+package repository
+
+import "task-management/internal/domain"
+
+type NotificationPreferenceRepository interface {
+	FindByUser(userID uint) (*domain.NotificationPreference, error)
+	FindByDigest(frequency domain.DigestFrequency) ([]domain.NotificationPreference, error)
+	Save(preference *domain.NotificationPreference) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "task-management/internal/domain"
+
+type NotificationRepository interface {
+	Create(notification *domain.Notification) error
+	GetByID(id uint) (*domain.Notification, error)
+	GetByUser(userID uint, unreadOnly bool, limit, offset int) ([]domain.Notification, int64, error)
+	Exists(userID, taskID uint, notificationType domain.NotificationType) (bool, error)
+	MarkRead(id uint) error
+	MarkAllRead(userID uint) error
+}
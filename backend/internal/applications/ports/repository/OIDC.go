@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type UserIdentityRepository interface {
+	Find(issuer, subject string) (*domain.UserIdentity, error)
+	Create(identity *domain.UserIdentity) error
+	ListByUser(userID uint) ([]domain.UserIdentity, error)
+}
+
+type OIDCLoginStateRepository interface {
+	Create(state *domain.OIDCLoginState) error
+	// Take mengambil lalu menghapus state supaya satu state hanya bisa dipakai sekali.
+	Take(state string) (*domain.OIDCLoginState, error)
+	DeleteExpired(before time.Time) error
+}
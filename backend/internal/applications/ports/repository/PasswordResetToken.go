@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type PasswordResetTokenRepository interface {
+	Create(token *domain.PasswordResetToken) error
+	FindByHash(tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkUsed menandai token belum terpakai sebagai terpakai, false jika sudah dipakai lebih dulu.
+	MarkUsed(id uint, at time.Time) (bool, error)
+	DeleteByUser(userID uint) error
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "task-management/internal/domain"
+
+// RateLimitStore menyimpan token bucket per key. Tersedia implementasi in-memory dan Redis.
+type RateLimitStore interface {
+	Take(key string, rule domain.RateLimit) (*domain.RateLimitResult, error)
+}
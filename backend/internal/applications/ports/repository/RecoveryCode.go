@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type RecoveryCodeRepository interface {
+	// Replace menghapus semua recovery code user lalu menyimpan yang baru.
+	Replace(userID uint, codes []domain.RecoveryCode) error
+	// Use menandai kode yang belum dipakai sebagai terpakai, false jika kode tidak ada atau sudah dipakai.
+	Use(userID uint, codeHash string, at time.Time) (bool, error)
+	CountUnused(userID uint) (int64, error)
+	DeleteByUser(userID uint) error
+}
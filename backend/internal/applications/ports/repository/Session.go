@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type SessionRepository interface {
+	Create(session *domain.Session) error
+	FindByID(id string) (*domain.Session, error)
+	// ListActiveByUser mengembalikan session yang belum dicabut dan belum kedaluwarsa.
+	ListActiveByUser(userID uint, now time.Time) ([]domain.Session, error)
+	// ListByUser mengembalikan semua session user termasuk yang sudah dicabut, untuk export data.
+	ListByUser(userID uint) ([]domain.Session, error)
+	Revoke(id string, at time.Time) error
+	RevokeAllByUser(userID uint, at time.Time) error
+	TouchLastSeen(id string, at time.Time) error
+	DeleteExpired(before time.Time) error
+}
@@ -7,8 +7,18 @@ import (
 
 type TaskRepository interface {
 	Create(task *domain.Task) error
+	CreateBatch(tasks []domain.Task) error
 	GetByID(id uint) (*domain.Task, error)
-	GetByUser(userID uint, status *domain.TaskStatus, deadline *time.Time) ([]domain.Task, error)
+	// GetByUser dan StreamByUser memfilter task dengan deadline sebelum dueBefore (eksklusif) jika tidak nil.
+	GetByUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time) ([]domain.Task, error)
+	StreamByUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time, fn func(task *domain.Task) error) error
+	GetDueBetween(from, to time.Time) ([]domain.Task, error)
+	GetOverdueByUser(userID uint, now time.Time) ([]domain.Task, error)
+	GetDueBetweenByUser(userID uint, from, to time.Time) ([]domain.Task, error)
+	GetCompletedBetweenByUser(userID uint, from, to time.Time) ([]domain.Task, error)
 	Update(task *domain.Task) error
 	Delete(id uint) error
+	// CountByUsers mengembalikan jumlah task per user, user tanpa task tidak ada di map.
+	CountByUsers(userIDs []uint) (map[uint]int64, error)
+	CountByStatus(userID uint) (map[domain.TaskStatus]int64, error)
 }
@@ -0,0 +1,19 @@
+package repository
+
+// Repositories adalah repository yang terikat ke satu transaksi.
+type Repositories struct {
+	Tasks             TaskRepository
+	Users             UserRepository
+	Notifications     NotificationRepository
+	ExternalTaskLinks ExternalTaskLinkRepository
+	LoginAudits       LoginAuditRepository
+	UserIdentities    UserIdentityRepository
+}
+
+// UnitOfWork menjalankan beberapa operasi repository dalam satu transaksi. Jika fn mengembalikan
+// error atau panic, semua perubahan di dalamnya dibatalkan. Di dalam fn hanya repository dari
+// argumen yang ikut transaksi, repository lain tetap berjalan sendiri-sendiri dan tidak boleh
+// dipakai di dalam fn (SQLite hanya punya satu koneksi yang sedang dipakai transaksi).
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
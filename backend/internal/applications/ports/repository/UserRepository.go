@@ -6,4 +6,19 @@ type UserRepository interface {
 	Create(user *domain.User) error
 	FindByUsername(username string) (*domain.User, error)
 	FindByID(id uint) (*domain.User, error)
+	Update(user *domain.User) error
+	CountByRole(role domain.Role) (int64, error)
+	// LockAdmins mengunci perubahan jumlah admin sampai transaksi selesai, dipanggil di dalam
+	// UnitOfWork sebelum CountByRole supaya cek-lalu-tulis tidak berjalan bersamaan.
+	LockAdmins() error
+	// UpdateTOTPStep menyimpan time step TOTP terakhir hanya jika step lebih besar dari yang tersimpan.
+	// false berarti step tersebut (atau yang lebih baru) sudah dipakai request lain.
+	UpdateTOTPStep(userID uint, step int64) (bool, error)
+	// Search mencari user berdasarkan username, nama atau email. Query kosong berarti semua user.
+	Search(query string, limit, offset int) ([]domain.User, int64, error)
+	// Delete menghapus user beserta semua data miliknya.
+	Delete(id uint) error
+	// Anonymize menyimpan user yang sudah dianonimkan dan menghapus semua data miliknya
+	// kecuali task dan link import task tersebut.
+	Anonymize(user *domain.User) error
 }
@@ -0,0 +1,13 @@
+package services
+
+import "task-management/internal/domain"
+
+// AccountService menghapus akun dan mengekspor data pribadi user.
+type AccountService interface {
+	// DeleteAccount menghapus akun user sendiri sesuai deletion policy. Password wajib
+	// untuk akun yang punya password, akun SSO tanpa password cukup sudah login.
+	DeleteAccount(userID uint, password string) error
+	// EraseUser sama dengan DeleteAccount tanpa cek password, dipakai admin.
+	EraseUser(userID uint) error
+	ExportData(userID uint) (*domain.PersonalData, error)
+}
@@ -0,0 +1,16 @@
+package services
+
+import "task-management/internal/domain"
+
+type AdminService interface {
+	ListUsers(query string, page, limit int) ([]domain.UserOverview, int64, error)
+	GetUser(id uint) (*domain.UserOverview, error)
+	// Deactivate memblokir login user dan mencabut semua session-nya.
+	Deactivate(actorID, id uint) error
+	Reactivate(actorID, id uint) error
+	// DeleteUser menghapus atau menganonimkan user sesuai deletion policy.
+	DeleteUser(actorID, id uint) error
+	// ResetPassword mengganti password user. Jika newPassword kosong, password sementara
+	// dibuat dan dikembalikan sekali supaya bisa diberikan ke user.
+	ResetPassword(id uint, newPassword string) (string, error)
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type APITokenService interface {
+	CreateToken(userID uint, name string, scopes []string, expiresAt *time.Time) (string, *domain.APIToken, error)
+	ListTokens(userID uint) ([]domain.APIToken, error)
+	RevokeToken(tokenID uint, userID uint) error
+	Authenticate(token string) (*domain.JWTClaims, error)
+}
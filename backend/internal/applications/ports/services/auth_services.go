@@ -3,7 +3,13 @@ package services
 import "task-management/internal/domain"
 
 type AuthService interface {
-	Register(name, username, password string) (*domain.User, error)
-	Login(username, password string) (string, *domain.User, error)
+	// Register tidak langsung menyimpan email, link verifikasi dikirim ke alamat tersebut lebih dulu.
+	Register(name, username, email, password string) (*domain.User, error)
+	// Login mengembalikan challenge token jika user mengaktifkan 2FA.
+	Login(username, password string, client domain.ClientInfo) (*domain.LoginResult, error)
+	VerifyTwoFactor(challengeToken, code string, client domain.ClientInfo) (*domain.LoginResult, error)
 	Me(userID uint) (*domain.User, error)
+	// CheckSession menolak token login milik user nonaktif, yang session-nya dicabut atau dibuat sebelum
+	// password terakhir diganti. Scope admin dibuang dari claims jika user bukan admin lagi.
+	CheckSession(claims *domain.JWTClaims) error
 }
@@ -0,0 +1,10 @@
+package services
+
+// BlobStorage menyimpan file biner (mis. avatar) berdasarkan key seperti "avatars/1/128.png".
+type BlobStorage interface {
+	Put(key string, data []byte) error
+	// Get mengembalikan nil jika key tidak ada.
+	Get(key string) ([]byte, error)
+	// Delete tidak mengembalikan error jika key tidak ada.
+	Delete(key string) error
+}
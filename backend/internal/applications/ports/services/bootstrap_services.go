@@ -0,0 +1,11 @@
+package services
+
+import "task-management/internal/domain"
+
+type BootstrapService interface {
+	// CreateAdmin membuat admin pertama. Hanya bisa dipakai dengan bootstrap token yang benar
+	// dan selama belum ada admin, setelah itu setup tertutup.
+	CreateAdmin(token string, setup domain.AdminSetup) (*domain.User, error)
+	// DefaultCredentialUsers mengembalikan username akun yang masih memakai password bawaan.
+	DefaultCredentialUsers() ([]string, error)
+}
@@ -0,0 +1,9 @@
+package services
+
+import "task-management/internal/domain"
+
+type CalendarService interface {
+	CreateFeedToken(userID uint) (string, error)
+	RevokeFeedToken(userID uint) error
+	GetFeedTasks(token string) ([]domain.Task, error)
+}
@@ -0,0 +1,11 @@
+package services
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type DigestService interface {
+	BuildDigest(userID uint, frequency domain.DigestFrequency, now time.Time) (*domain.Digest, error)
+	SendDigests(frequency domain.DigestFrequency, now time.Time) error
+}
@@ -0,0 +1,7 @@
+package services
+
+// ImageResizer memotong gambar menjadi persegi di tengah lalu mengecilkannya ke beberapa ukuran.
+type ImageResizer interface {
+	// ResizeSquare mengembalikan gambar PNG per ukuran (sisi dalam piksel).
+	ResizeSquare(data []byte, sizes []int) (map[int][]byte, error)
+}
@@ -0,0 +1,7 @@
+package services
+
+import "task-management/internal/domain"
+
+type ImportService interface {
+	ImportExternal(userID uint, source string, tasks []domain.ExternalTask, mapping domain.ImportMapping, dryRun bool) (*domain.ImportReport, error)
+}
@@ -3,6 +3,12 @@ package services
 import "task-management/internal/domain"
 
 type JWTService interface {
-	GenerateToken(user *domain.User) (string, error)
+	// GenerateToken membuat access token untuk session tertentu (claim jti).
+	GenerateToken(user *domain.User, sessionID string) (string, error)
 	ValidateToken(token string) (*domain.JWTClaims, error)
+	// GenerateChallengeToken membuat token berumur pendek untuk langkah kedua login 2FA.
+	GenerateChallengeToken(user *domain.User) (string, error)
+	ValidateChallengeToken(token string) (uint, error)
+	// JWKS mengembalikan public key verifikasi, kosong jika memakai HS256.
+	JWKS() domain.JWKSet
 }
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"task-management/internal/domain"
+	"time"
+)
+
+type NotificationService interface {
+	Notify(userID uint, taskID *uint, notificationType domain.NotificationType, message string) error
+	// SendEmail mengirim email untuk notifikasi yang sudah disimpan di dalam unit of work,
+	// dipanggil setelah transaksinya berhasil. Kegagalan kirim email hanya di-log.
+	SendEmail(notification *domain.Notification)
+	GetNotifications(userID uint, unreadOnly bool, page, limit int) ([]domain.Notification, int64, error)
+	MarkAsRead(notificationID uint, userID uint) error
+	MarkAllAsRead(userID uint) error
+	NotifyUpcomingDeadlines(window time.Duration) error
+	GetPreferences(userID uint) (*domain.NotificationPreference, error)
+	UpdatePreferences(preference *domain.NotificationPreference) error
+}
@@ -0,0 +1,13 @@
+package services
+
+import "task-management/internal/domain"
+
+// Notifier mengirim pesan ke user di luar aplikasi (mis. email).
+type Notifier interface {
+	SendTaskAssigned(user *domain.User, task *domain.Task) error
+	SendDeadlineReminder(user *domain.User, task *domain.Task) error
+	SendDigest(user *domain.User, digest *domain.Digest) error
+	SendPasswordReset(user *domain.User, reset *domain.PasswordReset) error
+	// SendEmailVerification dikirim ke verification.Email, bukan ke email user saat ini.
+	SendEmailVerification(user *domain.User, verification *domain.EmailVerification) error
+}
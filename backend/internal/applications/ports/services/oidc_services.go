@@ -0,0 +1,17 @@
+package services
+
+import "task-management/internal/domain"
+
+// OIDCProvider berkomunikasi dengan OIDC provider: discovery, redirect login, dan penukaran code.
+type OIDCProvider interface {
+	Issuer() string
+	AuthCodeURL(state, nonce, codeChallenge string) (string, error)
+	// Exchange menukar authorization code dengan token lalu memverifikasi ID token.
+	Exchange(code, codeVerifier string) (*domain.OIDCIdentity, error)
+}
+
+type OIDCService interface {
+	BeginLogin() (*domain.OIDCAuthorization, error)
+	CompleteLogin(state, code string, client domain.ClientInfo) (*domain.LoginResult, error)
+	PruneLoginStates() error
+}
@@ -0,0 +1,11 @@
+package services
+
+type PasswordService interface {
+	ChangePassword(userID uint, currentPassword, newPassword string) error
+	// RequestReset mengirim token reset lewat notifier. Tidak mengembalikan error jika user
+	// tidak ditemukan supaya endpoint tidak bisa dipakai untuk menebak username.
+	RequestReset(username string) error
+	ResetPassword(token, newPassword string) error
+	// ForcePassword mengganti password tanpa password lama, dipakai admin. Semua session user dicabut.
+	ForcePassword(userID uint, newPassword string) error
+}
@@ -0,0 +1,22 @@
+package services
+
+import "task-management/internal/domain"
+
+// EmailVerifier mengirim link verifikasi ke email yang belum dikonfirmasi. Email baru disimpan
+// di user setelah link dibuka, sampai saat itu tidak ada email lain yang dikirim ke alamat tersebut.
+type EmailVerifier interface {
+	SendVerification(user *domain.User, email string) error
+}
+
+type ProfileService interface {
+	EmailVerifier
+
+	// UpdateProfile mengubah profil user. Email baru belum dipakai sampai dikonfirmasi lewat
+	// link yang dikirim ke alamat tersebut, alamat itu dikembalikan sebagai pendingEmail.
+	UpdateProfile(userID uint, update domain.ProfileUpdate) (user *domain.User, pendingEmail string, err error)
+	VerifyEmail(token string) error
+	// SetAvatar menyimpan avatar dalam semua ukuran domain.AvatarSizes.
+	SetAvatar(userID uint, image []byte) (*domain.User, error)
+	Avatar(userID uint, size int) (*domain.Avatar, error)
+	DeleteAvatar(userID uint) (*domain.User, error)
+}
@@ -0,0 +1,8 @@
+package services
+
+// SecretCipher mengenkripsi secret yang harus bisa dibaca lagi (mis. secret TOTP) sebelum disimpan.
+type SecretCipher interface {
+	Encrypt(plain string) (string, error)
+	// Decrypt juga menerima nilai lama yang tersimpan tanpa enkripsi dan mengembalikannya apa adanya.
+	Decrypt(sealed string) (string, error)
+}
@@ -0,0 +1,14 @@
+package services
+
+import "task-management/internal/domain"
+
+type SessionService interface {
+	// Start membuat session baru untuk user dan mengembalikan access token yang membawa ID session.
+	Start(user *domain.User, client domain.ClientInfo) (string, error)
+	List(userID uint) ([]domain.Session, error)
+	Revoke(userID uint, sessionID string) error
+	RevokeAll(userID uint) error
+	// Check menolak token yang session-nya sudah dicabut atau kedaluwarsa.
+	Check(claims *domain.JWTClaims) error
+	PruneExpired() error
+}
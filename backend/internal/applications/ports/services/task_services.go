@@ -7,7 +7,9 @@ import (
 
 type TaskService interface {
 	CreateTask(userId uint, req *domain.Task) error
-	GetTasks(userId uint, status *domain.TaskStatus, deadline *time.Time) ([]domain.Task, error)
+	GetTasks(userId uint, status *domain.TaskStatus, dueBefore *time.Time) ([]domain.Task, error)
+	ExportTasks(userId uint, status *domain.TaskStatus, dueBefore *time.Time, fn func(task *domain.Task) error) error
+	ImportTasks(userId uint, tasks []domain.Task) (int, error)
 	UpdateTask(arg *domain.Task, userId uint) error
 	DeleteTask(taskId uint, userId uint) error
 	GetTaskById(taskId uint, userId uint) (*domain.Task, error)
@@ -0,0 +1,11 @@
+package services
+
+import "time"
+
+// TOTPService membuat dan memvalidasi kode TOTP (RFC 6238).
+type TOTPService interface {
+	GenerateSecret() (string, error)
+	URI(secret, account string) string
+	// Validate mengembalikan time step kode yang cocok, dipakai untuk menolak kode yang sama dipakai ulang.
+	Validate(secret, code string, at time.Time) (int64, bool)
+}
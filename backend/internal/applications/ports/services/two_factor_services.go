@@ -0,0 +1,12 @@
+package services
+
+import "task-management/internal/domain"
+
+type TwoFactorService interface {
+	Enroll(userID uint) (*domain.TwoFactorEnrollment, error)
+	Enable(userID uint, code string) ([]string, error)
+	Disable(userID uint, password, code string) error
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	// VerifyCode menerima kode TOTP atau recovery code.
+	VerifyCode(user *domain.User, code string) (bool, error)
+}
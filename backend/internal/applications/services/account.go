@@ -0,0 +1,226 @@
+package services
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var ErrLastAdmin = errors.New("the last admin account cannot be deleted")
+
+// exportPageSize adalah jumlah notifikasi yang dibaca per query saat export data.
+const exportPageSize = 100
+
+type accountService struct {
+	userRepo         repository.UserRepository
+	taskRepo         repository.TaskRepository
+	notificationRepo repository.NotificationRepository
+	preferenceRepo   repository.NotificationPreferenceRepository
+	sessionRepo      repository.SessionRepository
+	apiTokenRepo     repository.APITokenRepository
+	identityRepo     repository.UserIdentityRepository
+	auditRepo        repository.LoginAuditRepository
+	blobs            services.BlobStorage
+	uow              repository.UnitOfWork
+	policy           domain.DeletionPolicy
+}
+
+func NewAccountService(
+	userRepo repository.UserRepository,
+	taskRepo repository.TaskRepository,
+	notificationRepo repository.NotificationRepository,
+	preferenceRepo repository.NotificationPreferenceRepository,
+	sessionRepo repository.SessionRepository,
+	apiTokenRepo repository.APITokenRepository,
+	identityRepo repository.UserIdentityRepository,
+	auditRepo repository.LoginAuditRepository,
+	blobs services.BlobStorage,
+	uow repository.UnitOfWork,
+	policy domain.DeletionPolicy,
+) services.AccountService {
+	return &accountService{
+		userRepo:         userRepo,
+		taskRepo:         taskRepo,
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		sessionRepo:      sessionRepo,
+		apiTokenRepo:     apiTokenRepo,
+		identityRepo:     identityRepo,
+		auditRepo:        auditRepo,
+		blobs:            blobs,
+		uow:              uow,
+		policy:           policy,
+	}
+}
+
+// DeleteAccount implements services.AccountService.
+func (s *accountService) DeleteAccount(userID uint, password string) error {
+	user, err := s.find(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Password != "" && utils.CheckPassword(user.Password, password) != nil {
+		return ErrInvalidPassword
+	}
+
+	return s.erase(user, func(repos repository.Repositories) error {
+		if !user.IsAdmin() {
+			return nil
+		}
+
+		// tanpa admin lain, route /admin dan setup admin pertama tidak bisa dipakai lagi,
+		// dicek di transaksi yang sama dengan penghapusan dan dikunci supaya dua admin terakhir
+		// tidak bisa menghapus akunnya bersamaan
+		if err := repos.Users.LockAdmins(); err != nil {
+			return err
+		}
+
+		admins, err := repos.Users.CountByRole(domain.RoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		if admins <= 1 {
+			return ErrLastAdmin
+		}
+
+		return nil
+	})
+}
+
+// EraseUser implements services.AccountService.
+func (s *accountService) EraseUser(userID uint) error {
+	user, err := s.find(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.erase(user, nil)
+}
+
+// erase menjalankan check (opsional) lalu menghapus atau menganonimkan user dalam satu transaksi.
+// Login audit dicatat per username, bukan user_id, sehingga dihapus dengan username sebelum dianonimkan.
+// Avatar dihapus setelah transaksi berhasil karena file tidak ikut rollback.
+func (s *accountService) erase(user *domain.User, check func(repos repository.Repositories) error) error {
+	username := user.Username
+
+	err := s.uow.Do(func(repos repository.Repositories) error {
+		if check != nil {
+			if err := check(repos); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.LoginAudits.DeleteByUsername(username); err != nil {
+			return err
+		}
+
+		if s.policy == domain.DeletionAnonymize {
+			user.Anonymize(time.Now())
+			return repos.Users.Anonymize(user)
+		}
+
+		return repos.Users.Delete(user.ID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if err := deleteAvatarBlobs(s.blobs, user.ID); err != nil {
+		return err
+	}
+
+	logger.Info("account deleted", zap.Uint("user_id", user.ID), zap.String("policy", string(s.policy)))
+	return nil
+}
+
+// ExportData implements services.AccountService.
+func (s *accountService) ExportData(userID uint) (*domain.PersonalData, error) {
+	user, err := s.find(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &domain.PersonalData{
+		ExportedAt: time.Now(),
+		User:       *user,
+	}
+
+	if data.Tasks, err = s.taskRepo.GetByUser(userID, nil, nil); err != nil {
+		return nil, err
+	}
+
+	for offset := 0; ; offset += exportPageSize {
+		notifications, _, err := s.notificationRepo.GetByUser(userID, false, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		data.Notifications = append(data.Notifications, notifications...)
+
+		if len(notifications) < exportPageSize {
+			break
+		}
+	}
+
+	if data.NotificationPreference, err = s.preferenceRepo.FindByUser(userID); err != nil {
+		return nil, err
+	}
+
+	if data.Sessions, err = s.sessionRepo.ListByUser(userID); err != nil {
+		return nil, err
+	}
+
+	if data.APITokens, err = s.apiTokenRepo.ListByUser(userID); err != nil {
+		return nil, err
+	}
+
+	if data.Identities, err = s.identityRepo.ListByUser(userID); err != nil {
+		return nil, err
+	}
+
+	if data.LoginAudits, err = s.auditRepo.ListByUsername(user.Username); err != nil {
+		return nil, err
+	}
+
+	if user.AvatarUpdatedAt != nil {
+		// ukuran terbesar yang disimpan, avatar asli tidak disimpan
+		size := domain.AvatarSizes[len(domain.AvatarSizes)-1]
+
+		image, err := s.blobs.Get(avatarKey(userID, size))
+		if err != nil {
+			return nil, err
+		}
+
+		if image != nil {
+			data.Avatar = &domain.Avatar{
+				Data:        image,
+				ContentType: avatarContentType,
+				UpdatedAt:   *user.AvatarUpdatedAt,
+			}
+		}
+	}
+
+	return data, nil
+}
+
+func (s *accountService) find(userID uint) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/blob"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/adapter/storages/memory"
+	"task-management/internal/infra/db"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB membuat database SQLite in-memory dengan skema dari migrasi.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := db.Connect(config.DatabaseConfig{Driver: "sqlite", Name: ":memory:"})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = database.Close() })
+
+	migrator, err := db.NewMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return database.DB
+}
+
+func newTestAccountService(database *gorm.DB, policy domain.DeletionPolicy) (*accountService, repository.UserRepository, repository.LoginAuditRepository) {
+	users := storages.NewUserRepository(database)
+	audits := storages.NewLoginAuditRepository(database)
+
+	service := NewAccountService(
+		users,
+		storages.NewTaskRepository(database),
+		storages.NewNotificationRepository(database),
+		storages.NewNotificationPreferenceRepository(database),
+		storages.NewSessionRepository(database),
+		storages.NewAPITokenRepository(database),
+		storages.NewUserIdentityRepository(database),
+		audits,
+		blob.NewMemoryStorage(),
+		storages.NewUnitOfWork(database),
+		policy,
+	)
+
+	return service.(*accountService), users, audits
+}
+
+func TestAccountLoginAuditsAreExportedAndErased(t *testing.T) {
+	for _, policy := range []domain.DeletionPolicy{domain.DeletionDelete, domain.DeletionAnonymize} {
+		t.Run(string(policy), func(t *testing.T) {
+			service, users, audits := newTestAccountService(openTestDB(t), policy)
+
+			alice := &domain.User{Name: "Alice", Username: "alice", Password: ""}
+			if err := users.Create(alice); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, audit := range []domain.LoginAudit{
+				{Username: "alice", IP: "10.0.0.1", Reason: domain.LoginFailInvalidPassword},
+				{Username: "ALICE", IP: "10.0.0.2", Reason: domain.LoginFailThrottled},
+				{Username: "bob", IP: "10.0.0.3", Reason: domain.LoginFailUnknownUser},
+			} {
+				if err := audits.Create(&audit); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			data, err := service.ExportData(alice.ID)
+			if err != nil {
+				t.Fatalf("ExportData: %v", err)
+			}
+
+			if len(data.LoginAudits) != 2 || data.LoginAudits[0].IP != "10.0.0.1" {
+				t.Fatalf("exported login audits = %+v, want the two entries for alice", data.LoginAudits)
+			}
+
+			if err := service.DeleteAccount(alice.ID, ""); err != nil {
+				t.Fatalf("DeleteAccount: %v", err)
+			}
+
+			if left, _ := audits.ListByUsername("alice"); len(left) != 0 {
+				t.Errorf("login audits after deletion = %+v, want none", left)
+			}
+
+			if left, _ := audits.ListByUsername("bob"); len(left) != 1 {
+				t.Errorf("login audits of other users = %+v, want one", left)
+			}
+		})
+	}
+}
+
+func TestAccountLastAdminCannotBeDeleted(t *testing.T) {
+	service, users, _ := newTestAccountService(openTestDB(t), domain.DeletionDelete)
+
+	admin := &domain.User{Name: "Admin", Username: "admin", Role: domain.RoleAdmin}
+	if err := users.Create(admin); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.DeleteAccount(admin.ID, ""); !errors.Is(err, ErrLastAdmin) {
+		t.Fatalf("DeleteAccount last admin = %v, want ErrLastAdmin", err)
+	}
+
+	if user, _ := users.FindByID(admin.ID); user == nil {
+		t.Fatal("last admin was deleted")
+	}
+}
+
+func TestReservedUsername(t *testing.T) {
+	for username, reserved := range map[string]bool{
+		"deleted-12": true,
+		"Deleted-x":  true,
+		"deleted":    false,
+		"undeleted-": false,
+	} {
+		if got := domain.IsReservedUsername(username); got != reserved {
+			t.Errorf("IsReservedUsername(%q) = %v, want %v", username, got, reserved)
+		}
+	}
+
+	oidc := &oidcService{userRepo: memory.NewUserRepository(memory.NewTaskRepository())}
+
+	username, err := oidc.uniqueUsername(&domain.OIDCIdentity{PreferredUsername: "Deleted-deleted-Ann"})
+	if err != nil || username != "ann" {
+		t.Fatalf("uniqueUsername = %q, %v, want %q", username, err, "ann")
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrCannotActOnSelf = errors.New("admins cannot deactivate or delete their own account")
+)
+
+type adminService struct {
+	userRepo  repository.UserRepository
+	taskRepo  repository.TaskRepository
+	sessions  services.SessionService
+	passwords services.PasswordService
+	accounts  services.AccountService
+}
+
+func NewAdminService(
+	userRepo repository.UserRepository,
+	taskRepo repository.TaskRepository,
+	sessions services.SessionService,
+	passwords services.PasswordService,
+	accounts services.AccountService,
+) services.AdminService {
+	return &adminService{
+		userRepo:  userRepo,
+		taskRepo:  taskRepo,
+		sessions:  sessions,
+		passwords: passwords,
+		accounts:  accounts,
+	}
+}
+
+// ListUsers implements services.AdminService.
+func (s *adminService) ListUsers(query string, page int, limit int) ([]domain.UserOverview, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	users, total, err := s.userRepo.Search(query, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	counts, err := s.taskRepo.CountByUsers(ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	overviews := make([]domain.UserOverview, 0, len(users))
+	for _, user := range users {
+		user.Password = ""
+		overviews = append(overviews, domain.UserOverview{
+			User:  user,
+			Tasks: domain.TaskCounts{Total: counts[user.ID]},
+		})
+	}
+
+	return overviews, total, nil
+}
+
+// GetUser implements services.AdminService.
+func (s *adminService) GetUser(id uint) (*domain.UserOverview, error) {
+	user, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus, err := s.taskRepo.CountByStatus(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, count := range byStatus {
+		total += count
+	}
+
+	user.Password = ""
+
+	return &domain.UserOverview{
+		User:  *user,
+		Tasks: domain.TaskCounts{Total: total, ByStatus: byStatus},
+	}, nil
+}
+
+// Deactivate implements services.AdminService.
+func (s *adminService) Deactivate(actorID uint, id uint) error {
+	if actorID == id {
+		return ErrCannotActOnSelf
+	}
+
+	user, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	if user.IsActive() {
+		now := time.Now()
+		user.DeactivatedAt = &now
+
+		if err := s.userRepo.Update(user); err != nil {
+			return err
+		}
+
+		logger.Info("user deactivated", zap.Uint("user_id", id), zap.Uint("admin_id", actorID))
+	}
+
+	// token yang sudah terbit juga ditolak middleware, session dicabut supaya tidak
+	// hidup lagi jika user diaktifkan kembali
+	return s.sessions.RevokeAll(id)
+}
+
+// Reactivate implements services.AdminService.
+func (s *adminService) Reactivate(actorID uint, id uint) error {
+	user, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsActive() {
+		user.DeactivatedAt = nil
+
+		if err := s.userRepo.Update(user); err != nil {
+			return err
+		}
+
+		logger.Info("user reactivated", zap.Uint("user_id", id), zap.Uint("admin_id", actorID))
+	}
+
+	return nil
+}
+
+// DeleteUser implements services.AdminService.
+func (s *adminService) DeleteUser(actorID uint, id uint) error {
+	if actorID == id {
+		return ErrCannotActOnSelf
+	}
+
+	// penghapusan mengikuti deletion policy yang sama dengan hapus akun sendiri
+	if err := s.accounts.EraseUser(id); err != nil {
+		return err
+	}
+
+	logger.Info("user deleted", zap.Uint("user_id", id), zap.Uint("admin_id", actorID))
+	return nil
+}
+
+// ResetPassword implements services.AdminService.
+func (s *adminService) ResetPassword(id uint, newPassword string) (string, error) {
+	if _, err := s.find(id); err != nil {
+		return "", err
+	}
+
+	generated := newPassword == ""
+
+	if generated {
+		secret, err := utils.GenerateToken(16)
+		if err != nil {
+			return "", err
+		}
+
+		// prefix tetap supaya password sementara selalu lolos aturan huruf besar, kecil, angka dan simbol
+		newPassword = "Tx9!" + secret
+	}
+
+	if err := s.passwords.ForcePassword(id, newPassword); err != nil {
+		return "", err
+	}
+
+	if !generated {
+		return "", nil
+	}
+
+	return newPassword, nil
+}
+
+func (s *adminService) find(id uint) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// lastUsedInterval membatasi update last_used_at agar tidak menulis ke DB di setiap request.
+const lastUsedInterval = time.Minute
+
+type apiTokenService struct {
+	tokenRepo repository.APITokenRepository
+	userRepo  repository.UserRepository
+}
+
+func NewAPITokenService(tokenRepo repository.APITokenRepository, userRepo repository.UserRepository) services.APITokenService {
+	return &apiTokenService{
+		tokenRepo: tokenRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// CreateToken implements services.APITokenService.
+func (s *apiTokenService) CreateToken(userID uint, name string, scopes []string, expiresAt *time.Time) (string, *domain.APIToken, error) {
+	for _, scope := range scopes {
+		if !domain.IsValidScope(scope) {
+			return "", nil, fmt.Errorf("invalid scope: %s", scope)
+		}
+	}
+
+	if slices.Contains(scopes, domain.ScopeAdmin) {
+		user, err := s.userRepo.FindByID(userID)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if user == nil || !user.IsAdmin() {
+			return "", nil, fmt.Errorf("invalid scope: %s", domain.ScopeAdmin)
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return "", nil, errors.New("expiry must be in the future")
+	}
+
+	secret, err := utils.GenerateToken(20)
+	if err != nil {
+		return "", nil, err
+	}
+
+	plain := domain.APITokenPrefix + secret
+
+	token := &domain.APIToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:len(domain.APITokenPrefix)+8],
+		TokenHash: utils.HashToken(plain),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := s.tokenRepo.Create(token); err != nil {
+		return "", nil, err
+	}
+
+	return plain, token, nil
+}
+
+// ListTokens implements services.APITokenService.
+func (s *apiTokenService) ListTokens(userID uint) ([]domain.APIToken, error) {
+	return s.tokenRepo.ListByUser(userID)
+}
+
+// RevokeToken implements services.APITokenService.
+func (s *apiTokenService) RevokeToken(tokenID uint, userID uint) error {
+	token, err := s.tokenRepo.FindByID(tokenID)
+	if err != nil {
+		return err
+	}
+
+	if token == nil || token.UserID != userID {
+		return errors.New("token not found")
+	}
+
+	return s.tokenRepo.Revoke(tokenID, time.Now())
+}
+
+// Authenticate implements services.APITokenService.
+func (s *apiTokenService) Authenticate(plain string) (*domain.JWTClaims, error) {
+	if !strings.HasPrefix(plain, domain.APITokenPrefix) {
+		return nil, errors.New("invalid token")
+	}
+
+	token, err := s.tokenRepo.FindByHash(utils.HashToken(plain))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if token == nil || !token.IsActive(now) {
+		return nil, errors.New("invalid token")
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || !user.IsActive() {
+		return nil, errors.New("invalid token")
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > lastUsedInterval {
+		if err := s.tokenRepo.TouchLastUsed(token.ID, now); err != nil {
+			logger.Warn("failed to update token last used", zap.Uint("token_id", token.ID), zap.Error(err))
+		}
+	}
+
+	scopes := token.Scopes
+
+	// token admin milik user yang sudah bukan admin tetap bisa dipakai tanpa scope admin
+	if !user.IsAdmin() {
+		scopes = domain.WithoutScope(scopes, domain.ScopeAdmin)
+	}
+
+	return &domain.JWTClaims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Scopes:   scopes,
+	}, nil
+}
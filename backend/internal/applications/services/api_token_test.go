@@ -0,0 +1,223 @@
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/utils"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func newTestAPITokenService(t *testing.T) (*apiTokenService, repository.UserRepository, *gorm.DB) {
+	t.Helper()
+
+	database := openTestDB(t)
+	users := storages.NewUserRepository(database)
+
+	service := NewAPITokenService(storages.NewAPITokenRepository(database), users)
+	return service.(*apiTokenService), users, database
+}
+
+func createTokenUser(t *testing.T, users repository.UserRepository, username string, role domain.Role) *domain.User {
+	t.Helper()
+
+	user := &domain.User{Name: username, Username: username, Password: "x", Role: role}
+	if err := users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func TestAPITokenIsStoredHashed(t *testing.T) {
+	service, users, database := newTestAPITokenService(t)
+	alice := createTokenUser(t, users, "alice", domain.RoleUser)
+
+	plain, created, err := service.CreateToken(alice.ID, "cron", []string{domain.ScopeTasksRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(plain, domain.APITokenPrefix) || !strings.HasPrefix(plain, created.Prefix) {
+		t.Fatalf("plain = %q, prefix = %q", plain, created.Prefix)
+	}
+
+	var stored domain.APIToken
+	if err := database.First(&stored, created.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if stored.TokenHash != utils.HashToken(plain) || strings.Contains(stored.TokenHash, plain[len(domain.APITokenPrefix):]) {
+		t.Errorf("stored hash = %q, want the hash of the token", stored.TokenHash)
+	}
+
+	// baris di database tidak boleh berisi token asli di kolom mana pun
+	var row map[string]any
+	if err := database.Table("api_tokens").Where("id = ?", created.ID).Take(&row).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for column, value := range row {
+		if s, ok := value.(string); ok && strings.Contains(s, plain) {
+			t.Errorf("column %s contains the plain token", column)
+		}
+	}
+
+	listed, err := service.ListTokens(alice.ID)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("ListTokens = %v, %v", listed, err)
+	}
+
+	body, err := json.Marshal(listed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(body), plain) || strings.Contains(string(body), stored.TokenHash) {
+		t.Errorf("listed tokens = %s, must not contain the token or its hash", body)
+	}
+}
+
+func TestAPITokenAuthenticate(t *testing.T) {
+	service, users, _ := newTestAPITokenService(t)
+	alice := createTokenUser(t, users, "alice", domain.RoleUser)
+
+	plain, _, err := service.CreateToken(alice.ID, "cron", []string{domain.ScopeTasksRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := service.Authenticate(plain)
+	if err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+
+	if claims.UserID != alice.ID || !slices.Equal(claims.Scopes, []string{domain.ScopeTasksRead}) {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	for _, token := range []string{plain + "x", strings.TrimPrefix(plain, domain.APITokenPrefix), utils.HashToken(plain)} {
+		if _, err := service.Authenticate(token); err == nil || err.Error() != "invalid token" {
+			t.Errorf("Authenticate(%q) = %v, want invalid token", token, err)
+		}
+	}
+}
+
+func TestAPITokenRevokedOrExpiredIsRejected(t *testing.T) {
+	service, users, database := newTestAPITokenService(t)
+	alice := createTokenUser(t, users, "alice", domain.RoleUser)
+
+	revoked, revokedToken, err := service.CreateToken(alice.ID, "revoked", []string{domain.ScopeTasksRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.RevokeToken(revokedToken.ID, alice.ID); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Hour)
+	expired, expiredToken, err := service.CreateToken(alice.ID, "expired", []string{domain.ScopeTasksRead}, &expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Authenticate(expired); err != nil {
+		t.Fatalf("Authenticate before expiry: %v", err)
+	}
+
+	// CreateToken menolak expiry di masa lalu, jadi token dibuat kedaluwarsa langsung di database
+	if err := database.Model(&domain.APIToken{}).Where("id = ?", expiredToken.ID).Update("expires_at", time.Now().Add(-time.Second)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for name, token := range map[string]string{"revoked": revoked, "expired": expired} {
+		if _, err := service.Authenticate(token); err == nil || err.Error() != "invalid token" {
+			t.Errorf("%s token: Authenticate = %v, want invalid token", name, err)
+		}
+	}
+}
+
+func TestAPITokenRevokeOnlyOwnTokens(t *testing.T) {
+	service, users, _ := newTestAPITokenService(t)
+	alice := createTokenUser(t, users, "alice", domain.RoleUser)
+	bob := createTokenUser(t, users, "bob", domain.RoleUser)
+
+	plain, token, err := service.CreateToken(alice.ID, "cron", []string{domain.ScopeTasksRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.RevokeToken(token.ID, bob.ID); err == nil || err.Error() != "token not found" {
+		t.Fatalf("RevokeToken by another user = %v, want token not found", err)
+	}
+
+	if _, err := service.Authenticate(plain); err != nil {
+		t.Errorf("token stopped working after a foreign revoke: %v", err)
+	}
+}
+
+func TestAPITokenOfDeactivatedUserIsRejected(t *testing.T) {
+	service, users, _ := newTestAPITokenService(t)
+	alice := createTokenUser(t, users, "alice", domain.RoleUser)
+
+	plain, _, err := service.CreateToken(alice.ID, "cron", []string{domain.ScopeTasksRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	alice.DeactivatedAt = &now
+	if err := users.Update(alice); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Authenticate(plain); err == nil || err.Error() != "invalid token" {
+		t.Fatalf("Authenticate = %v, want invalid token", err)
+	}
+}
+
+func TestAPITokenScopes(t *testing.T) {
+	service, users, _ := newTestAPITokenService(t)
+	alice := createTokenUser(t, users, "alice", domain.RoleUser)
+	root := createTokenUser(t, users, "root", domain.RoleAdmin)
+
+	if _, _, err := service.CreateToken(alice.ID, "bad", []string{"tasks:delete"}, nil); err == nil || err.Error() != "invalid scope: tasks:delete" {
+		t.Errorf("unknown scope: %v", err)
+	}
+
+	if _, _, err := service.CreateToken(alice.ID, "admin", []string{domain.ScopeAdmin}, nil); err == nil || err.Error() != "invalid scope: admin" {
+		t.Errorf("admin scope for a regular user: %v", err)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	if _, _, err := service.CreateToken(alice.ID, "past", []string{domain.ScopeTasksRead}, &past); err == nil || err.Error() != "expiry must be in the future" {
+		t.Errorf("past expiry: %v", err)
+	}
+
+	plain, _, err := service.CreateToken(root.ID, "admin", []string{domain.ScopeAdmin, domain.ScopeTasksRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// admin yang diturunkan tetap bisa memakai token, tapi tanpa scope admin
+	root.Role = domain.RoleUser
+	if err := users.Update(root); err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := service.Authenticate(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(claims.Scopes, []string{domain.ScopeTasksRead}) {
+		t.Errorf("scopes = %v, want the admin scope dropped", claims.Scopes)
+	}
+}
@@ -7,55 +7,176 @@ import (
 	"task-management/internal/domain"
 	"task-management/internal/infra/logger"
 	"task-management/internal/utils"
+	"time"
 
 	"go.uber.org/zap"
 )
 
 var (
-	ErrUserExists      = errors.New("username is already exists")
-	ErrInvalidPassword = errors.New("invalid password")
+	ErrUserExists       = errors.New("username is already exists")
+	ErrUsernameReserved = errors.New("username is reserved")
+	ErrInvalidPassword  = errors.New("invalid password")
+	ErrSessionExpired   = errors.New("session expired, please log in again")
+	ErrUserDeactivated  = errors.New("account is deactivated")
 )
 
 type authService struct {
-	repo repository.UserRepository
-	jwt  services.JWTService
+	repo      repository.UserRepository
+	jwt       services.JWTService
+	sessions  services.SessionService
+	twoFactor services.TwoFactorService
+	passwords domain.PasswordPolicy
+	guard     *loginGuard
+	emails    services.EmailVerifier
 }
 
-func NewAuthService(repo repository.UserRepository, jwt services.JWTService) services.AuthService {
+func NewAuthService(
+	repo repository.UserRepository,
+	jwt services.JWTService,
+	sessions services.SessionService,
+	twoFactor services.TwoFactorService,
+	passwords domain.PasswordPolicy,
+	attempts repository.LoginAttemptStore,
+	audit repository.LoginAuditRepository,
+	policy domain.LoginPolicy,
+	emails services.EmailVerifier,
+) services.AuthService {
 	return &authService{
-		repo: repo,
-		jwt:  jwt,
+		repo:      repo,
+		jwt:       jwt,
+		sessions:  sessions,
+		twoFactor: twoFactor,
+		passwords: passwords,
+		guard: &loginGuard{
+			store:  attempts,
+			audit:  audit,
+			policy: policy,
+		},
+		emails: emails,
 	}
 }
 
 // Login implements services.AuthService.
-func (a *authService) Login(username string, password string) (string, *domain.User, error) {
+func (a *authService) Login(username string, password string, client domain.ClientInfo) (*domain.LoginResult, error) {
+	now := time.Now()
+	ip := client.IP
+
+	if err := a.guard.begin(username, ip, now); err != nil {
+		return nil, err
+	}
+
 	user, err := a.repo.FindByUsername(username)
 	if err != nil {
-		return "", nil, err
+		a.guard.release(username, ip)
+		return nil, err
 	}
 
 	if user == nil {
 		logger.Info("user not found: ", zap.String("username", username))
-		return "", nil, errors.New("user not found")
+
+		if err := a.guard.fail(username, ip, domain.LoginFailUnknownUser, now); err != nil {
+			return nil, err
+		}
+
+		return nil, errors.New("user not found")
 	}
 
 	if utils.CheckPassword(user.Password, password) != nil {
-		return "", nil, ErrInvalidPassword
+		if err := a.guard.fail(username, ip, domain.LoginFailInvalidPassword, now); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrInvalidPassword
+	}
+
+	a.guard.release(username, ip)
+
+	// dicek setelah password supaya status akun tidak bocor ke orang yang tidak tahu password
+	if !user.IsActive() {
+		return nil, ErrUserDeactivated
+	}
+
+	// counter login gagal belum di-reset sampai kode 2FA benar, supaya tebakan kode
+	// tidak bisa diulang tanpa batas dengan login ulang memakai password yang benar
+	if user.TOTPEnabled {
+		challenge, err := a.jwt.GenerateChallengeToken(user)
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.LoginResult{Token: challenge, TwoFactorRequired: true}, nil
+	}
+
+	return a.completeLogin(user, client)
+}
+
+// VerifyTwoFactor implements services.AuthService.
+func (a *authService) VerifyTwoFactor(challengeToken string, code string, client domain.ClientInfo) (*domain.LoginResult, error) {
+	userID, err := a.jwt.ValidateChallengeToken(challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := a.repo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	now := time.Now()
+
+	if err := a.guard.begin(user.Username, client.IP, now); err != nil {
+		return nil, err
+	}
+
+	ok, err := a.twoFactor.VerifyCode(user, code)
+	if err != nil {
+		a.guard.release(user.Username, client.IP)
+		return nil, err
+	}
+
+	if !ok {
+		if err := a.guard.fail(user.Username, client.IP, domain.LoginFailInvalidCode, now); err != nil {
+			return nil, err
+		}
+
+		return nil, ErrInvalidTwoFactorCode
 	}
 
-	token, err := a.jwt.GenerateToken(user)
+	a.guard.release(user.Username, client.IP)
+
+	return a.completeLogin(user, client)
+}
+
+func (a *authService) completeLogin(user *domain.User, client domain.ClientInfo) (*domain.LoginResult, error) {
+	// user bisa dinonaktifkan di antara login dan verifikasi 2FA
+	if !user.IsActive() {
+		return nil, ErrUserDeactivated
+	}
+
+	if err := a.guard.succeed(user.Username); err != nil {
+		logger.Warn("failed to reset login attempts", zap.String("username", user.Username), zap.Error(err))
+	}
+
+	token, err := a.sessions.Start(user, client)
 
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 
 	user.Password = ""
-	return token, user, nil
+	return &domain.LoginResult{Token: token, User: user}, nil
 }
 
 // Register implements services.AuthService.
-func (a *authService) Register(name string, username string, password string) (*domain.User, error) {
+func (a *authService) Register(name string, username string, email string, password string) (*domain.User, error) {
+	if domain.IsReservedUsername(username) {
+		return nil, ErrUsernameReserved
+	}
+
 	// check if username already exists
 	existingUser, err := a.repo.FindByUsername(username)
 	if err != nil {
@@ -66,28 +187,69 @@ func (a *authService) Register(name string, username string, password string) (*
 		return nil, ErrUserExists
 	}
 
+	if err := a.passwords.Validate(password); err != nil {
+		return nil, err
+	}
+
 	hashedPassword, err := utils.HashPassword(password)
 
 	if err != nil {
 		return nil, err
 	}
 
+	// email baru disimpan setelah dikonfirmasi supaya tidak ada email terkirim ke alamat milik orang lain
 	user := &domain.User{
 		Name:     name,
 		Username: username,
 		Password: hashedPassword,
+		Role:     domain.RoleUser,
 	}
 
 	if err := a.repo.Create(user); err != nil {
 		return nil, err
 	}
 
+	if email != "" {
+		if err := a.emails.SendVerification(user, email); err != nil {
+			return nil, err
+		}
+	}
+
 	// hide password, jangan kirim ke handler
 	user.Password = ""
 
 	return user, nil
 }
 
+// CheckSession implements services.AuthService.
+func (a *authService) CheckSession(claims *domain.JWTClaims) error {
+	user, err := a.repo.FindByID(claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrSessionExpired
+	}
+
+	if !user.IsActive() {
+		return ErrUserDeactivated
+	}
+
+	// hak admin yang sudah dicabut langsung berlaku tanpa menunggu token kedaluwarsa
+	if !user.IsAdmin() {
+		claims.Scopes = domain.WithoutScope(claims.Scopes, domain.ScopeAdmin)
+	}
+
+	// iat JWT hanya presisi detik, jadi waktu ganti password dibulatkan ke bawah
+	if user.PasswordChangedAt != nil && claims.IssuedAt != nil &&
+		claims.IssuedAt.Time.Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return ErrSessionExpired
+	}
+
+	return a.sessions.Check(claims)
+}
+
 func (a *authService) Me(userID uint) (*domain.User, error) {
 	user, err := a.repo.FindByID(userID)
 	if err != nil {
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"slices"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/blob"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/adapter/storages/memory"
+	"task-management/internal/infra/security"
+	"testing"
+	"time"
+)
+
+// email dari register hanya menerima link verifikasi sampai alamatnya dikonfirmasi
+func TestRegisterEmailIsUsedAfterVerification(t *testing.T) {
+	database := openTestDB(t)
+	users := storages.NewUserRepository(database)
+	mails := newMailbox()
+
+	jwt := security.NewJWTAdapter(security.NewHMACKeySet("secret"), time.Hour)
+	sessions := NewSessionService(storages.NewSessionRepository(database), jwt, time.Hour)
+	profiles := NewProfileService(users, storages.NewEmailVerificationTokenRepository(database), mails, blob.NewMemoryStorage(), nil, time.Hour, "")
+	policy := domain.PasswordPolicy{MinLength: 10}
+
+	auth := NewAuthService(users, jwt, sessions, nil, policy, memory.NewLoginAttemptStore(), &auditRecorder{},
+		domain.LoginPolicy{MaxAttempts: 5, Window: time.Minute, Lockout: time.Minute}, profiles)
+	passwords := NewPasswordService(users, storages.NewPasswordResetTokenRepository(database), sessions, mails, policy, time.Hour, "")
+
+	registered, err := auth.Register("Alice", "alice", "victim@example.com", "long password")
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	stored, _ := users.FindByID(registered.ID)
+	if registered.Email != "" || stored.Email != "" {
+		t.Errorf("email saved before verification: response %q, stored %q", registered.Email, stored.Email)
+	}
+
+	if err := passwords.RequestReset("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(mails.sent, []string{"verify:victim@example.com"}) {
+		t.Fatalf("sent = %v, want only the verification link", mails.sent)
+	}
+
+	if err := profiles.VerifyEmail(mails.verification.Token); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+
+	if err := passwords.RequestReset("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	if last := mails.sent[len(mails.sent)-1]; last != "reset:alice" {
+		t.Errorf("sent = %v, want a reset email after verification", mails.sent)
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"crypto/subtle"
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrSetupDisabled         = errors.New("setup is disabled")
+	ErrSetupCompleted        = errors.New("setup has already been completed")
+	ErrInvalidBootstrapToken = errors.New("invalid bootstrap token")
+)
+
+type bootstrapService struct {
+	userRepo  repository.UserRepository
+	uow       repository.UnitOfWork
+	passwords domain.PasswordPolicy
+	token     string
+}
+
+// NewBootstrapService membuat service setup admin pertama. Jika token kosong, setup dinonaktifkan.
+func NewBootstrapService(userRepo repository.UserRepository, uow repository.UnitOfWork, passwords domain.PasswordPolicy, token string) services.BootstrapService {
+	return &bootstrapService{
+		userRepo:  userRepo,
+		uow:       uow,
+		passwords: passwords,
+		token:     token,
+	}
+}
+
+// CreateAdmin implements services.BootstrapService.
+func (s *bootstrapService) CreateAdmin(token string, setup domain.AdminSetup) (*domain.User, error) {
+	if s.token == "" {
+		return nil, ErrSetupDisabled
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		return nil, ErrInvalidBootstrapToken
+	}
+
+	if domain.IsReservedUsername(setup.Username) {
+		return nil, ErrUsernameReserved
+	}
+
+	var user *domain.User
+
+	// cek admin dan username dijalankan di transaksi yang sama dengan insert admin, kunci admins
+	// membuat request setup yang bersamaan menunggu sampai admin pertama tersimpan
+	err := s.uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Users.LockAdmins(); err != nil {
+			return err
+		}
+
+		admins, err := repos.Users.CountByRole(domain.RoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		if admins > 0 {
+			return ErrSetupCompleted
+		}
+
+		existing, err := repos.Users.FindByUsername(setup.Username)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			return ErrUserExists
+		}
+
+		if err := s.passwords.Validate(setup.Password); err != nil {
+			return err
+		}
+
+		// password bawaan lama tidak boleh dipakai lagi walaupun denylist tidak dikonfigurasi
+		for _, credential := range domain.DefaultCredentials {
+			if setup.Password == credential.Password {
+				return &domain.PasswordPolicyError{Problems: []string{"is too common or has appeared in a data breach"}}
+			}
+		}
+
+		hashed, err := utils.HashPassword(setup.Password)
+		if err != nil {
+			return err
+		}
+
+		user = &domain.User{
+			Name:     setup.Name,
+			Username: setup.Username,
+			Email:    setup.Email,
+			Password: hashed,
+			Role:     domain.RoleAdmin,
+		}
+
+		return repos.Users.Create(user)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("initial admin created", zap.String("username", user.Username))
+
+	user.Password = ""
+	return user, nil
+}
+
+// DefaultCredentialUsers implements services.BootstrapService.
+func (s *bootstrapService) DefaultCredentialUsers() ([]string, error) {
+	var usernames []string
+
+	for _, credential := range domain.DefaultCredentials {
+		user, err := s.userRepo.FindByUsername(credential.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		if user != nil && utils.CheckPassword(user.Password, credential.Password) == nil {
+			usernames = append(usernames, user.Username)
+		}
+	}
+
+	return usernames, nil
+}
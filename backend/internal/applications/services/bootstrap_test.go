@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages/memory"
+	"testing"
+)
+
+// noTransaction menggantikan transaksi database untuk repository selain task dan user.
+type noTransaction struct{}
+
+func (noTransaction) Do(fn func(repos repository.Repositories) error) error {
+	return fn(repository.Repositories{})
+}
+
+func TestBootstrapCreatesSingleAdminConcurrently(t *testing.T) {
+	tasks := memory.NewTaskRepository()
+	users := memory.NewUserRepository(tasks)
+	bootstrap := NewBootstrapService(users, memory.NewUnitOfWork(tasks, users, noTransaction{}), domain.PasswordPolicy{MinLength: 8}, "token")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = bootstrap.CreateAdmin("token", domain.AdminSetup{
+				Name:     "Admin",
+				Username: "admin" + string(rune('a'+i)),
+				Password: "correct horse battery",
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrSetupCompleted):
+			t.Errorf("CreateAdmin error = %v, want ErrSetupCompleted", err)
+		}
+	}
+
+	if admins, _ := users.CountByRole(domain.RoleAdmin); created != 1 || admins != 1 {
+		t.Fatalf("created %d admins (%d stored), want exactly one", created, admins)
+	}
+}
+
+func TestBootstrapRejectsInvalidToken(t *testing.T) {
+	tasks := memory.NewTaskRepository()
+	users := memory.NewUserRepository(tasks)
+	uow := memory.NewUnitOfWork(tasks, users, noTransaction{})
+
+	if _, err := NewBootstrapService(users, uow, domain.PasswordPolicy{}, "").CreateAdmin("", domain.AdminSetup{}); !errors.Is(err, ErrSetupDisabled) {
+		t.Errorf("CreateAdmin without token configured = %v, want ErrSetupDisabled", err)
+	}
+
+	if _, err := NewBootstrapService(users, uow, domain.PasswordPolicy{}, "token").CreateAdmin("wrong", domain.AdminSetup{}); !errors.Is(err, ErrInvalidBootstrapToken) {
+		t.Errorf("CreateAdmin with wrong token = %v, want ErrInvalidBootstrapToken", err)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/utils"
+)
+
+type calendarService struct {
+	feedRepo repository.CalendarFeedRepository
+	taskRepo repository.TaskRepository
+	userRepo repository.UserRepository
+}
+
+func NewCalendarService(feedRepo repository.CalendarFeedRepository, taskRepo repository.TaskRepository, userRepo repository.UserRepository) services.CalendarService {
+	return &calendarService{
+		feedRepo: feedRepo,
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateFeedToken implements services.CalendarService.
+// Token lama otomatis tidak berlaku lagi karena hash-nya ditimpa.
+func (s *calendarService) CreateFeedToken(userID uint) (string, error) {
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	feed, err := s.feedRepo.FindByUser(userID)
+	if err != nil {
+		return "", err
+	}
+
+	if feed == nil {
+		feed = &domain.CalendarFeed{UserID: userID}
+	}
+
+	feed.TokenHash = utils.HashToken(token)
+
+	if err := s.feedRepo.Save(feed); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RevokeFeedToken implements services.CalendarService.
+func (s *calendarService) RevokeFeedToken(userID uint) error {
+	return s.feedRepo.DeleteByUser(userID)
+}
+
+// GetFeedTasks implements services.CalendarService.
+// Hanya task yang memiliki deadline yang dikembalikan. Token milik user yang dinonaktifkan
+// diperlakukan sama dengan token yang tidak ada.
+func (s *calendarService) GetFeedTasks(token string) ([]domain.Task, error) {
+	feed, err := s.feedRepo.FindByTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	if feed == nil {
+		return nil, errors.New("calendar feed not found")
+	}
+
+	user, err := s.userRepo.FindByID(feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil || !user.IsActive() {
+		return nil, errors.New("calendar feed not found")
+	}
+
+	tasks, err := s.taskRepo.GetByUser(feed.UserID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	withDeadline := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Deadline != nil {
+			withDeadline = append(withDeadline, task)
+		}
+	}
+
+	return withDeadline, nil
+}
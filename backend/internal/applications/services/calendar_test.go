@@ -0,0 +1,45 @@
+package services
+
+import (
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"testing"
+	"time"
+)
+
+func TestCalendarFeedOfDeactivatedUserIsNotFound(t *testing.T) {
+	database := openTestDB(t)
+
+	users := storages.NewUserRepository(database)
+	tasks := storages.NewTaskRepository(database)
+	service := NewCalendarService(storages.NewCalendarFeedRepository(database), tasks, users)
+
+	user := &domain.User{Name: "Alice", Username: "alice", Password: "hash"}
+	if err := users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	if err := tasks.Create(&domain.Task{UserID: user.ID, CreatedBy: user.ID, Title: "Due", Status: domain.ToDo, Deadline: &deadline}); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := service.CreateFeedToken(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed, err := service.GetFeedTasks(token); err != nil || len(feed) != 1 {
+		t.Fatalf("GetFeedTasks = %d tasks, %v, want 1", len(feed), err)
+	}
+
+	now := time.Now()
+	user.DeactivatedAt = &now
+	if err := users.Update(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.GetFeedTasks(token); err == nil || err.Error() != "calendar feed not found" {
+		t.Fatalf("GetFeedTasks of deactivated user = %v, want calendar feed not found", err)
+	}
+}
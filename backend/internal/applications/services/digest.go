@@ -0,0 +1,157 @@
+package services
+
+import (
+	"fmt"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type digestService struct {
+	taskRepo       repository.TaskRepository
+	preferenceRepo repository.NotificationPreferenceRepository
+	userRepo       repository.UserRepository
+	notifier       services.Notifier
+	hour           int
+}
+
+// NewDigestService membuat service digest. hour adalah jam pengiriman (0-23) di zona waktu
+// masing-masing user, digest weekly dikirim hari Senin pada jam yang sama.
+func NewDigestService(
+	taskRepo repository.TaskRepository,
+	preferenceRepo repository.NotificationPreferenceRepository,
+	userRepo repository.UserRepository,
+	notifier services.Notifier,
+	hour int,
+) services.DigestService {
+	return &digestService{
+		taskRepo:       taskRepo,
+		preferenceRepo: preferenceRepo,
+		userRepo:       userRepo,
+		notifier:       notifier,
+		hour:           hour,
+	}
+}
+
+// BuildDigest implements services.DigestService.
+// Periode daily mencakup satu hari kalender terakhir, weekly 7 hari, dihitung di zona waktu now
+// sehingga perpindahan DST tetap tepat. Task "due soon" memakai rentang yang sama ke depan.
+func (d *digestService) BuildDigest(userID uint, frequency domain.DigestFrequency, now time.Time) (*domain.Digest, error) {
+	var days int
+
+	switch frequency {
+	case domain.DigestDaily:
+		days = 1
+	case domain.DigestWeekly:
+		days = 7
+	default:
+		return nil, fmt.Errorf("unsupported digest frequency %q", frequency)
+	}
+
+	from := now.AddDate(0, 0, -days)
+
+	overdue, err := d.taskRepo.GetOverdueByUser(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	dueSoon, err := d.taskRepo.GetDueBetweenByUser(userID, now, now.AddDate(0, 0, days))
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := d.taskRepo.GetCompletedBetweenByUser(userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Digest{
+		Frequency: frequency,
+		From:      from,
+		To:        now,
+		Overdue:   overdue,
+		DueSoon:   dueSoon,
+		Completed: completed,
+	}, nil
+}
+
+// SendDigests implements services.DigestService.
+// Dipanggil setiap jam, digest hanya dikirim ke user yang jam lokalnya sama dengan jam pengiriman.
+// Digest kosong tidak dikirim, dan kegagalan satu user tidak menghentikan user lain.
+func (d *digestService) SendDigests(frequency domain.DigestFrequency, now time.Time) error {
+	preferences, err := d.preferenceRepo.FindByDigest(frequency)
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+
+	for _, preference := range preferences {
+		if err := d.sendDigest(preference.UserID, frequency, now); err != nil {
+			logger.Warn("failed to send digest",
+				zap.Uint("user_id", preference.UserID),
+				zap.String("frequency", string(frequency)),
+				zap.Error(err),
+			)
+			continue
+		}
+		sent++
+	}
+
+	logger.Info("Digest job finished",
+		zap.String("frequency", string(frequency)),
+		zap.Int("subscribers", len(preferences)),
+		zap.Int("processed", sent),
+	)
+
+	return nil
+}
+
+func (d *digestService) sendDigest(userID uint, frequency domain.DigestFrequency, now time.Time) error {
+	user, err := d.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || !user.HasVerifiedEmail() {
+		return nil
+	}
+
+	at, due := d.sendTime(user, frequency, now)
+	if !due {
+		return nil
+	}
+
+	digest, err := d.BuildDigest(userID, frequency, at)
+	if err != nil {
+		return err
+	}
+
+	if len(digest.Overdue) == 0 && len(digest.DueSoon) == 0 && len(digest.Completed) == 0 {
+		return nil
+	}
+
+	return d.notifier.SendDigest(user, digest)
+}
+
+// sendTime mengembalikan jam pengiriman hari ini di zona waktu user jika now jatuh di jam tersebut.
+// Periode digest berakhir di jam pengiriman, bukan di now, supaya periode berurutan tidak
+// berlubang atau tumpang tindih walaupun job terlambat beberapa menit.
+func (d *digestService) sendTime(user *domain.User, frequency domain.DigestFrequency, now time.Time) (time.Time, bool) {
+	local := now.In(utils.LocationOrUTC(user.Timezone))
+
+	if local.Hour() != d.hour {
+		return time.Time{}, false
+	}
+
+	if frequency == domain.DigestWeekly && local.Weekday() != time.Monday {
+		return time.Time{}, false
+	}
+
+	return time.Date(local.Year(), local.Month(), local.Day(), d.hour, 0, 0, 0, local.Location()), true
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"slices"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"testing"
+	"time"
+)
+
+func TestSendDigestsUsesUserTimezone(t *testing.T) {
+	database := openTestDB(t)
+
+	tasks := storages.NewTaskRepository(database)
+	users := storages.NewUserRepository(database)
+	preferences := storages.NewNotificationPreferenceRepository(database)
+	mails := newMailbox()
+	service := NewDigestService(tasks, preferences, users, mails, 7)
+
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	newYork, _ := time.LoadLocation("America/New_York")
+	verified := time.Now()
+
+	for _, user := range []*domain.User{
+		{Name: "Alice", Username: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified, Timezone: "Asia/Jakarta"},
+		{Name: "Bob", Username: "bob", Email: "bob@example.com", EmailVerifiedAt: &verified, Timezone: "America/New_York"},
+	} {
+		if err := users.Create(user); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := preferences.Save(&domain.NotificationPreference{UserID: user.ID, EmailEnabled: true, Digest: domain.DigestDaily}); err != nil {
+			t.Fatal(err)
+		}
+
+		// selesai 23 jam dan 25 jam sebelum jam 07:00 lokal hari Senin 19 Oktober
+		location := jakarta
+		if user.Username == "bob" {
+			location = newYork
+		}
+
+		for _, completed := range []time.Time{
+			time.Date(2026, 10, 18, 8, 0, 0, 0, location),
+			time.Date(2026, 10, 18, 6, 0, 0, 0, location),
+		} {
+			task := &domain.Task{UserID: user.ID, CreatedBy: user.ID, Title: "Done " + completed.String(), Status: domain.Done, CompletedAt: &completed}
+			if err := tasks.Create(task); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// 00:00 UTC adalah 07:00 di Jakarta dan 20:00 hari sebelumnya di New York
+	if err := service.SendDigests(domain.DigestDaily, time.Date(2026, 10, 19, 0, 5, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(mails.sent, []string{"digest:alice"}) {
+		t.Fatalf("sent at 00:05 UTC = %v, want only alice", mails.sent)
+	}
+
+	// 11:00 UTC adalah 07:00 EDT di New York
+	if err := service.SendDigests(domain.DigestDaily, time.Date(2026, 10, 19, 11, 5, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(mails.sent, []string{"digest:alice", "digest:bob"}) {
+		t.Fatalf("sent at 11:05 UTC = %v, want alice then bob", mails.sent)
+	}
+
+	for i, location := range []*time.Location{jakarta, newYork} {
+		digest := mails.digests[i]
+		wantTo := time.Date(2026, 10, 19, 7, 0, 0, 0, location)
+
+		if !digest.To.Equal(wantTo) || !digest.From.Equal(wantTo.AddDate(0, 0, -1)) || digest.To.Location().String() != location.String() {
+			t.Errorf("%s digest period = %s - %s, want the local day before %s", mails.sent[i], digest.From, digest.To, wantTo)
+		}
+
+		if len(digest.Completed) != 1 {
+			t.Errorf("%s completed = %d tasks, want 1 inside the local day", mails.sent[i], len(digest.Completed))
+		}
+	}
+}
+
+func TestWeeklyDigestIsSentOnLocalMonday(t *testing.T) {
+	database := openTestDB(t)
+
+	users := storages.NewUserRepository(database)
+	service := NewDigestService(storages.NewTaskRepository(database), storages.NewNotificationPreferenceRepository(database), users, newMailbox(), 7)
+
+	// Senin 07:00 di Jakarta masih Minggu di UTC
+	user := &domain.User{Timezone: "Asia/Jakarta"}
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	if _, due := service.(*digestService).sendTime(user, domain.DigestWeekly, monday); !due {
+		t.Error("weekly digest not due on local Monday")
+	}
+
+	if _, due := service.(*digestService).sendTime(user, domain.DigestWeekly, monday.AddDate(0, 0, 1)); due {
+		t.Error("weekly digest due on local Tuesday")
+	}
+}
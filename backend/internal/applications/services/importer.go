@@ -0,0 +1,346 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultStatusMapping dipakai jika status eksternal tidak ada di mapping dari user.
+var defaultStatusMapping = map[string]domain.TaskStatus{
+	"to do":       domain.ToDo,
+	"todo":        domain.ToDo,
+	"backlog":     domain.ToDo,
+	"open":        domain.ToDo,
+	"in progress": domain.InProgress,
+	"doing":       domain.InProgress,
+	"in review":   domain.InProgress,
+	"review":      domain.InProgress,
+	"done":        domain.Done,
+	"closed":      domain.Done,
+	"resolved":    domain.Done,
+	"completed":   domain.Done,
+}
+
+type importService struct {
+	uow          repository.UnitOfWork
+	userRepo     repository.UserRepository
+	notification services.NotificationService
+}
+
+// NewImportService membuat service import. Task, link import dan notifikasi setiap item
+// ditulis lewat uow dalam satu transaksi.
+func NewImportService(
+	uow repository.UnitOfWork,
+	userRepo repository.UserRepository,
+	notification services.NotificationService,
+) services.ImportService {
+	return &importService{
+		uow:          uow,
+		userRepo:     userRepo,
+		notification: notification,
+	}
+}
+
+// ImportExternal implements services.ImportService.
+// Import bersifat idempotent: task yang external ID-nya sudah pernah diimport oleh user
+// yang sama akan di-update, bukan dibuat ulang.
+func (s *importService) ImportExternal(userID uint, source string, tasks []domain.ExternalTask, mapping domain.ImportMapping, dryRun bool) (*domain.ImportReport, error) {
+	importer, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if importer == nil {
+		return nil, ErrUserNotFound
+	}
+
+	report := &domain.ImportReport{Total: len(tasks)}
+	assignees := &assigneeResolver{
+		users:           s.userRepo,
+		importerID:      userID,
+		canAssignOthers: importer.IsAdmin(),
+		mapping:         mapping.Assignees,
+		cache:           map[string]uint{},
+	}
+
+	for i, external := range tasks {
+		created, err := s.importOne(userID, source, external, mapping, assignees, dryRun)
+		if err != nil {
+			var reason *importError
+			if !errors.As(err, &reason) {
+				return report, err
+			}
+
+			report.Failures = append(report.Failures, domain.ImportFailure{
+				Index:      i,
+				ExternalID: external.ExternalID,
+				Reason:     reason.Error(),
+			})
+			continue
+		}
+
+		if created {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+
+	return report, nil
+}
+
+// importError adalah kesalahan pada satu item yang tidak menghentikan proses import.
+type importError struct {
+	reason string
+}
+
+func (e *importError) Error() string {
+	return e.reason
+}
+
+func (s *importService) importOne(
+	userID uint,
+	source string,
+	external domain.ExternalTask,
+	mapping domain.ImportMapping,
+	assignees *assigneeResolver,
+	dryRun bool,
+) (bool, error) {
+	if external.ExternalID == "" {
+		return false, &importError{"external id is missing"}
+	}
+
+	title := strings.TrimSpace(external.Title)
+	if title == "" {
+		return false, &importError{"title is required"}
+	}
+
+	if len(title) > 255 {
+		return false, &importError{"title is longer than 255 characters"}
+	}
+
+	ownerID, err := assignees.resolve(external.Assignees)
+	if err != nil {
+		return false, err
+	}
+
+	var created bool
+	var notification *domain.Notification
+
+	err = s.uow.Do(func(repos repository.Repositories) error {
+		created, notification = false, nil
+
+		link, err := repos.ExternalTaskLinks.Find(userID, source, external.ExternalID)
+		if err != nil {
+			return err
+		}
+
+		var task *domain.Task
+
+		if link != nil {
+			task, err = repos.Tasks.GetByID(link.TaskID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+
+		created = task == nil
+		if created {
+			task = &domain.Task{CreatedBy: userID}
+		}
+
+		previousOwner := task.UserID
+		previousStatus := task.Status
+
+		task.UserID = ownerID
+		task.Title = title
+		task.Description = external.Description
+		task.Status, err = mapStatus(external.Status, mapping.Statuses)
+		if err != nil {
+			return err
+		}
+
+		task.Deadline = external.Deadline
+		task.Labels = mapLabels(external.Labels, mapping.Labels)
+
+		if task.Status != previousStatus {
+			task.CompletedAt = nil
+
+			if task.Status == domain.Done {
+				now := time.Now()
+				task.CompletedAt = &now
+			}
+		}
+
+		if dryRun {
+			return nil
+		}
+
+		if created {
+			if err := repos.Tasks.Create(task); err != nil {
+				return err
+			}
+		} else if err := repos.Tasks.Update(task); err != nil {
+			return err
+		}
+
+		// link yang task-nya sudah dihapus diarahkan ke task yang baru dibuat
+		if link == nil {
+			link = &domain.ExternalTaskLink{
+				ImportedBy: userID,
+				Source:     source,
+				ExternalID: external.ExternalID,
+			}
+		}
+
+		if link.TaskID != task.ID {
+			link.TaskID = task.ID
+
+			if err := repos.ExternalTaskLinks.Save(link); err != nil {
+				return err
+			}
+		}
+
+		if task.UserID == userID || task.UserID == previousOwner {
+			return nil
+		}
+
+		taskID := task.ID
+		notification = &domain.Notification{
+			UserID:  task.UserID,
+			TaskID:  &taskID,
+			Type:    domain.NotificationTaskAssigned,
+			Message: fmt.Sprintf("Task %q was assigned to you", task.Title),
+		}
+
+		return repos.Notifications.Create(notification)
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	// email dikirim setelah task dan notifikasinya tersimpan
+	if notification != nil {
+		s.notification.SendEmail(notification)
+	}
+
+	return created, nil
+}
+
+// assigneeResolver memetakan assignee eksternal ke user lokal.
+type assigneeResolver struct {
+	users           repository.UserRepository
+	importerID      uint
+	canAssignOthers bool
+	mapping         map[string]string
+	cache           map[string]uint
+}
+
+// resolve memakai assignee eksternal pertama yang ada di mapping dari user. Nama eksternal
+// tidak pernah dipakai langsung sebagai username, supaya file export tidak bisa menaruh task
+// di akun orang lain. Jika tidak ada yang cocok, task menjadi milik user yang melakukan import.
+// Hanya admin yang boleh memetakan assignee ke user lain.
+func (r *assigneeResolver) resolve(externals []string) (uint, error) {
+	for _, external := range externals {
+		username := lookup(r.mapping, external)
+		if username == "" {
+			continue
+		}
+
+		id, ok := r.cache[username]
+		if !ok {
+			user, err := r.users.FindByUsername(username)
+			if err != nil {
+				return 0, err
+			}
+
+			if user != nil {
+				id = user.ID
+			}
+
+			r.cache[username] = id
+		}
+
+		if id == 0 {
+			return 0, &importError{fmt.Sprintf("assignee %q is mapped to unknown user %q", external, username)}
+		}
+
+		if id != r.importerID && !r.canAssignOthers {
+			return 0, &importError{fmt.Sprintf("assignee %q is mapped to another user, only admins can assign imported tasks to other users", external)}
+		}
+
+		return id, nil
+	}
+
+	return r.importerID, nil
+}
+
+// mapStatus memakai mapping dari user terlebih dahulu, lalu mapping bawaan.
+// Status yang tidak dikenal dianggap To Do.
+func mapStatus(external string, mapping map[string]domain.TaskStatus) (domain.TaskStatus, error) {
+	key := strings.TrimSpace(external)
+
+	for k, status := range mapping {
+		if !strings.EqualFold(k, key) {
+			continue
+		}
+
+		switch status {
+		case domain.ToDo, domain.InProgress, domain.Done:
+			return status, nil
+		default:
+			return "", &importError{fmt.Sprintf("status %q is mapped to unknown status %q", external, status)}
+		}
+	}
+
+	if status, ok := defaultStatusMapping[strings.ToLower(key)]; ok {
+		return status, nil
+	}
+
+	return domain.ToDo, nil
+}
+
+// mapLabels mengganti label sesuai mapping. Label yang dipetakan ke string kosong dibuang,
+// label yang tidak ada di mapping dipakai apa adanya.
+func mapLabels(external []string, mapping map[string]string) []string {
+	var labels []string
+	seen := map[string]bool{}
+
+	for _, label := range external {
+		local := label
+		if mapped, ok := lookupKey(mapping, label); ok {
+			local = mapped
+		}
+
+		if local == "" || seen[local] {
+			continue
+		}
+
+		seen[local] = true
+		labels = append(labels, local)
+	}
+
+	return labels
+}
+
+func lookup(mapping map[string]string, key string) string {
+	value, _ := lookupKey(mapping, key)
+	return value
+}
+
+func lookupKey(mapping map[string]string, key string) (string, bool) {
+	for k, v := range mapping {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages/memory"
+	"testing"
+)
+
+func newTestResolver(t *testing.T, canAssignOthers bool, mapping map[string]string) *assigneeResolver {
+	t.Helper()
+
+	users := memory.NewUserRepository(memory.NewTaskRepository())
+	for _, username := range []string{"importer", "admin", "bob"} {
+		if err := users.Create(&domain.User{Username: username}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &assigneeResolver{
+		users:           users,
+		importerID:      1,
+		canAssignOthers: canAssignOthers,
+		mapping:         mapping,
+		cache:           map[string]uint{},
+	}
+}
+
+// nama assignee di file export tidak boleh dipakai langsung sebagai username lokal
+func TestAssigneeResolverIgnoresUnmappedNames(t *testing.T) {
+	resolver := newTestResolver(t, true, nil)
+
+	id, err := resolver.resolve([]string{"admin", "bob"})
+	if err != nil || id != 1 {
+		t.Fatalf("resolve unmapped = %d, %v, want importer", id, err)
+	}
+}
+
+func TestAssigneeResolverNonAdminCannotAssignOthers(t *testing.T) {
+	resolver := newTestResolver(t, false, map[string]string{"Bob Jira": "bob", "Me": "importer"})
+
+	_, err := resolver.resolve([]string{"bob jira"})
+
+	var reason *importError
+	if !errors.As(err, &reason) {
+		t.Fatalf("resolve to other user = %v, want importError", err)
+	}
+
+	if id, err := resolver.resolve([]string{"me"}); err != nil || id != 1 {
+		t.Fatalf("resolve to self = %d, %v, want importer", id, err)
+	}
+}
+
+func TestAssigneeResolverAdminMapping(t *testing.T) {
+	resolver := newTestResolver(t, true, map[string]string{"Bob Jira": "bob", "Ghost": "nobody"})
+
+	if id, err := resolver.resolve([]string{"unknown", "Bob Jira"}); err != nil || id != 3 {
+		t.Fatalf("resolve mapped = %d, %v, want bob (3)", id, err)
+	}
+
+	var reason *importError
+	if _, err := resolver.resolve([]string{"ghost"}); !errors.As(err, &reason) {
+		t.Fatalf("resolve to unknown user = %v, want importError", err)
+	}
+}
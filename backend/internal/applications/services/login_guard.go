@@ -0,0 +1,182 @@
+package services
+
+import (
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// loginGuard melacak login gagal per username dan per IP. Setelah FreeAttempts gagal,
+// percobaan berikutnya harus menunggu delay yang naik dua kali lipat setiap gagal,
+// dan setelah MaxAttempts key tersebut dikunci selama Lockout.
+//
+// Setiap percobaan dihitung sebelum password diperiksa (begin) lalu diselesaikan dengan fail
+// atau release, sehingga percobaan paralel tidak bisa melewati MaxAttempts.
+type loginGuard struct {
+	store  repository.LoginAttemptStore
+	audit  repository.LoginAuditRepository
+	policy domain.LoginPolicy
+}
+
+// usernameKey tidak membedakan huruf besar kecil karena "Admin" dan "admin" adalah akun yang
+// sama di MySQL, tanpa ini setiap variasi huruf mendapat counter sendiri.
+func usernameKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// check mengembalikan LoginThrottledError jika username atau IP sedang diperlambat/dikunci.
+func (g *loginGuard) check(username, ip string, now time.Time) error {
+	for _, key := range []string{usernameKey(username), ipKey(ip)} {
+		attempt, err := g.store.Get(key)
+		if err != nil {
+			return err
+		}
+
+		if attempt == nil {
+			continue
+		}
+
+		if wait := g.retryAfter(attempt, now); wait > 0 {
+			g.record(username, ip, domain.LoginFailThrottled)
+			return &domain.LoginThrottledError{RetryAfter: wait}
+		}
+	}
+
+	return nil
+}
+
+func (g *loginGuard) retryAfter(attempt *domain.LoginAttempt, now time.Time) time.Duration {
+	if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+		return attempt.LockedUntil.Sub(now)
+	}
+
+	if attempt.Failures <= g.policy.FreeAttempts {
+		return 0
+	}
+
+	delay := g.policy.BaseDelay << (attempt.Failures - g.policy.FreeAttempts - 1)
+	if delay <= 0 || delay > g.policy.MaxDelay {
+		delay = g.policy.MaxDelay
+	}
+
+	if next := attempt.LastFailure.Add(delay); next.After(now) {
+		return next.Sub(now)
+	}
+
+	return 0
+}
+
+type attemptLimit struct {
+	key         string
+	maxAttempts int
+}
+
+func (g *loginGuard) limits(username, ip string) []attemptLimit {
+	return []attemptLimit{
+		{key: usernameKey(username), maxAttempts: g.policy.MaxAttempts},
+		{key: ipKey(ip), maxAttempts: g.policy.IPMaxAttempts},
+	}
+}
+
+// begin mengecek throttle lalu menghitung percobaan ini di counter username dan IP secara atomik.
+// Jika percobaan paralel sudah menghabiskan jatah, percobaan ini ditolak tanpa memeriksa password.
+// Setiap begin yang berhasil harus diikuti fail atau release. Percobaan yang ditolak membatalkan
+// semua hitungannya sendiri, supaya IP yang diperlambat tidak ikut menaikkan counter akun korban.
+func (g *loginGuard) begin(username, ip string, now time.Time) error {
+	if err := g.check(username, ip, now); err != nil {
+		return err
+	}
+
+	var counted []string
+
+	for _, limit := range g.limits(username, ip) {
+		attempt, err := g.store.Increment(limit.key, now, g.policy.Window)
+		if err != nil {
+			g.releaseKeys(counted)
+			return err
+		}
+
+		counted = append(counted, limit.key)
+
+		if limit.maxAttempts > 0 && attempt.Failures > limit.maxAttempts {
+			g.releaseKeys(counted)
+			g.record(username, ip, domain.LoginFailThrottled)
+			return &domain.LoginThrottledError{RetryAfter: g.policy.Lockout}
+		}
+	}
+
+	return nil
+}
+
+// fail mencatat audit log dan mengunci key yang sudah mencapai batas. Counter sudah dinaikkan di begin.
+func (g *loginGuard) fail(username, ip, reason string, now time.Time) error {
+	g.record(username, ip, reason)
+
+	for _, limit := range g.limits(username, ip) {
+		attempt, err := g.store.Get(limit.key)
+		if err != nil {
+			return err
+		}
+
+		if attempt == nil || limit.maxAttempts <= 0 || attempt.Failures < limit.maxAttempts {
+			continue
+		}
+
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			continue
+		}
+
+		if err := g.store.Lock(limit.key, now.Add(g.policy.Lockout)); err != nil {
+			return err
+		}
+
+		logger.Warn("login locked", zap.String("key", limit.key), zap.Int("failures", attempt.Failures))
+	}
+
+	return nil
+}
+
+// release membatalkan hitungan dari begin untuk percobaan yang tidak gagal
+// (password benar, atau error di luar kredensial).
+func (g *loginGuard) release(username, ip string) {
+	var keys []string
+	for _, limit := range g.limits(username, ip) {
+		keys = append(keys, limit.key)
+	}
+
+	g.releaseKeys(keys)
+}
+
+func (g *loginGuard) releaseKeys(keys []string) {
+	for _, key := range keys {
+		if err := g.store.Decrement(key); err != nil {
+			logger.Warn("failed to release login attempt", zap.String("key", key), zap.Error(err))
+		}
+	}
+}
+
+// succeed menghapus counter username. Counter IP tidak di-reset supaya login berhasil
+// ke satu akun tidak membuka kembali percobaan ke akun lain dari IP yang sama.
+func (g *loginGuard) succeed(username string) error {
+	return g.store.Delete(usernameKey(username))
+}
+
+func (g *loginGuard) record(username, ip, reason string) {
+	err := g.audit.Create(&domain.LoginAudit{
+		Username: username,
+		IP:       ip,
+		Reason:   reason,
+	})
+
+	if err != nil {
+		logger.Error("failed to write login audit", zap.Error(err))
+	}
+}
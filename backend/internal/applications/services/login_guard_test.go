@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages/memory"
+	"testing"
+	"time"
+)
+
+type auditRecorder struct {
+	mu     sync.Mutex
+	audits []domain.LoginAudit
+}
+
+func (r *auditRecorder) Create(audit *domain.LoginAudit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.audits = append(r.audits, *audit)
+	return nil
+}
+
+func (r *auditRecorder) ListByUsername(username string) ([]domain.LoginAudit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var audits []domain.LoginAudit
+	for _, audit := range r.audits {
+		if strings.EqualFold(audit.Username, username) {
+			audits = append(audits, audit)
+		}
+	}
+
+	return audits, nil
+}
+
+func (r *auditRecorder) DeleteByUsername(username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.audits[:0]
+	for _, audit := range r.audits {
+		if !strings.EqualFold(audit.Username, username) {
+			kept = append(kept, audit)
+		}
+	}
+
+	r.audits = kept
+	return nil
+}
+
+func newTestGuard(maxAttempts int) *loginGuard {
+	return &loginGuard{
+		store: memory.NewLoginAttemptStore(),
+		audit: &auditRecorder{},
+		policy: domain.LoginPolicy{
+			MaxAttempts:   maxAttempts,
+			IPMaxAttempts: 1000,
+			FreeAttempts:  1000,
+			Window:        15 * time.Minute,
+			Lockout:       15 * time.Minute,
+			BaseDelay:     time.Second,
+			MaxDelay:      30 * time.Second,
+		},
+	}
+}
+
+func isThrottled(err error) bool {
+	var throttled *domain.LoginThrottledError
+	return errors.As(err, &throttled)
+}
+
+func TestLoginGuardLocksAfterMaxAttempts(t *testing.T) {
+	guard := newTestGuard(3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if err := guard.begin("alice", "10.0.0.1", now); err != nil {
+			t.Fatalf("attempt %d: begin = %v", i+1, err)
+		}
+
+		if err := guard.fail("alice", "10.0.0.1", domain.LoginFailInvalidPassword, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := guard.begin("alice", "10.0.0.2", now); !isThrottled(err) {
+		t.Fatalf("begin after lockout = %v, want LoginThrottledError", err)
+	}
+
+	// lockout berakhir dan window lama sudah lewat, counter dimulai dari awal
+	later := now.Add(16 * time.Minute)
+	if err := guard.begin("alice", "10.0.0.1", later); err != nil {
+		t.Fatalf("begin after lockout expired = %v", err)
+	}
+}
+
+func TestLoginGuardUsernameIsCaseInsensitive(t *testing.T) {
+	guard := newTestGuard(2)
+	now := time.Now()
+
+	for _, username := range []string{"Admin", "ADMIN"} {
+		if err := guard.begin(username, "10.0.0.1", now); err != nil {
+			t.Fatal(err)
+		}
+
+		_ = guard.fail(username, "10.0.0.1", domain.LoginFailInvalidPassword, now)
+	}
+
+	if err := guard.begin("admin", "10.0.0.1", now); !isThrottled(err) {
+		t.Fatalf("begin with other casing = %v, want LoginThrottledError", err)
+	}
+}
+
+func TestLoginGuardReleaseDoesNotCount(t *testing.T) {
+	guard := newTestGuard(2)
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if err := guard.begin("alice", "10.0.0.1", now); err != nil {
+			t.Fatalf("attempt %d: begin = %v", i+1, err)
+		}
+
+		guard.release("alice", "10.0.0.1")
+	}
+
+	attempt, _ := guard.store.Get(usernameKey("alice"))
+	if attempt.Failures != 0 {
+		t.Fatalf("failures after released attempts = %d, want 0", attempt.Failures)
+	}
+}
+
+// IP yang sudah melewati batasnya tidak boleh menaikkan counter username yang dicoba
+func TestLoginGuardThrottledIPDoesNotCountUsername(t *testing.T) {
+	guard := newTestGuard(3)
+	guard.policy.IPMaxAttempts = 1
+	now := time.Now()
+
+	if err := guard.begin("bob", "10.0.0.9", now); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := guard.begin("alice", "10.0.0.9", now); !isThrottled(err) {
+			t.Fatalf("attempt %d from throttled IP = %v, want LoginThrottledError", i+1, err)
+		}
+	}
+
+	if attempt, _ := guard.store.Get(usernameKey("alice")); attempt != nil && attempt.Failures != 0 {
+		t.Fatalf("victim failures = %d, want 0", attempt.Failures)
+	}
+
+	if err := guard.begin("alice", "10.0.0.1", now); err != nil {
+		t.Fatalf("begin from another IP = %v, want allowed", err)
+	}
+}
+
+// percobaan paralel tidak boleh lolos lebih dari MaxAttempts walaupun semuanya
+// melewati check sebelum ada yang gagal
+func TestLoginGuardLimitHoldsUnderConcurrency(t *testing.T) {
+	guard := newTestGuard(5)
+	now := time.Now()
+
+	var passed atomic.Int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := guard.begin("alice", "10.0.0.1", now); err == nil {
+				passed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := passed.Load(); got != 5 {
+		t.Fatalf("%d parallel attempts reached the password check, want 5", got)
+	}
+}
+
+func TestLoginGuardDelayAfterFreeAttempts(t *testing.T) {
+	guard := newTestGuard(100)
+	guard.policy.FreeAttempts = 1
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		if err := guard.begin("alice", "10.0.0.1", now); err != nil {
+			t.Fatalf("attempt %d: begin = %v", i+1, err)
+		}
+
+		_ = guard.fail("alice", "10.0.0.1", domain.LoginFailInvalidPassword, now)
+	}
+
+	if err := guard.begin("alice", "10.0.0.1", now); !isThrottled(err) {
+		t.Fatalf("begin inside delay = %v, want LoginThrottledError", err)
+	}
+
+	if err := guard.begin("alice", "10.0.0.1", now.Add(2*time.Second)); err != nil {
+		t.Fatalf("begin after delay = %v", err)
+	}
+}
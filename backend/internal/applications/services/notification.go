@@ -0,0 +1,232 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+type notificationService struct {
+	notificationRepo repository.NotificationRepository
+	preferenceRepo   repository.NotificationPreferenceRepository
+	taskRepo         repository.TaskRepository
+	userRepo         repository.UserRepository
+	notifier         services.Notifier
+}
+
+func NewNotificationService(
+	notificationRepo repository.NotificationRepository,
+	preferenceRepo repository.NotificationPreferenceRepository,
+	taskRepo repository.TaskRepository,
+	userRepo repository.UserRepository,
+	notifier services.Notifier,
+) services.NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		taskRepo:         taskRepo,
+		userRepo:         userRepo,
+		notifier:         notifier,
+	}
+}
+
+// Notify implements services.NotificationService.
+func (n *notificationService) Notify(userID uint, taskID *uint, notificationType domain.NotificationType, message string) error {
+	notification := &domain.Notification{
+		UserID:  userID,
+		TaskID:  taskID,
+		Type:    notificationType,
+		Message: message,
+	}
+
+	if err := n.notificationRepo.Create(notification); err != nil {
+		return err
+	}
+
+	n.SendEmail(notification)
+	return nil
+}
+
+// SendEmail implements services.NotificationService.
+// Kegagalan kirim email tidak membatalkan notifikasi in-app.
+func (n *notificationService) SendEmail(notification *domain.Notification) {
+	if notification.TaskID == nil {
+		return
+	}
+
+	if err := n.sendEmail(notification.UserID, *notification.TaskID, notification.Type); err != nil {
+		logger.Warn("failed to send notification email",
+			zap.Uint("user_id", notification.UserID),
+			zap.String("type", string(notification.Type)),
+			zap.Error(err),
+		)
+	}
+}
+
+func (n *notificationService) sendEmail(userID, taskID uint, notificationType domain.NotificationType) error {
+	if notificationType != domain.NotificationTaskAssigned && notificationType != domain.NotificationDeadlineApproach {
+		return nil
+	}
+
+	preference, err := n.GetPreferences(userID)
+	if err != nil {
+		return err
+	}
+
+	if !preference.EmailEnabled {
+		return nil
+	}
+
+	if notificationType == domain.NotificationTaskAssigned && !preference.TaskAssigned {
+		return nil
+	}
+
+	if notificationType == domain.NotificationDeadlineApproach && !preference.DeadlineReminder {
+		return nil
+	}
+
+	user, err := n.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || !user.HasVerifiedEmail() {
+		return nil
+	}
+
+	task, err := n.taskRepo.GetByID(taskID)
+	if err != nil {
+		return err
+	}
+
+	if notificationType == domain.NotificationTaskAssigned {
+		return n.notifier.SendTaskAssigned(user, task)
+	}
+
+	return n.notifier.SendDeadlineReminder(user, task)
+}
+
+// GetPreferences implements services.NotificationService.
+func (n *notificationService) GetPreferences(userID uint) (*domain.NotificationPreference, error) {
+	preference, err := n.preferenceRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if preference == nil {
+		return domain.DefaultNotificationPreference(userID), nil
+	}
+
+	return preference, nil
+}
+
+// UpdatePreferences implements services.NotificationService.
+func (n *notificationService) UpdatePreferences(preference *domain.NotificationPreference) error {
+	existing, err := n.preferenceRepo.FindByUser(preference.UserID)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		preference.ID = existing.ID
+	}
+
+	return n.preferenceRepo.Save(preference)
+}
+
+// GetNotifications implements services.NotificationService.
+func (n *notificationService) GetNotifications(userID uint, unreadOnly bool, page, limit int) ([]domain.Notification, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = defaultNotificationLimit
+	}
+
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+
+	return n.notificationRepo.GetByUser(userID, unreadOnly, limit, (page-1)*limit)
+}
+
+// MarkAsRead implements services.NotificationService.
+func (n *notificationService) MarkAsRead(notificationID uint, userID uint) error {
+	notification, err := n.notificationRepo.GetByID(notificationID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("notification not found")
+		}
+		return err
+	}
+
+	if notification.UserID != userID {
+		return errors.New("unauthorized")
+	}
+
+	return n.notificationRepo.MarkRead(notificationID)
+}
+
+// MarkAllAsRead implements services.NotificationService.
+func (n *notificationService) MarkAllAsRead(userID uint) error {
+	return n.notificationRepo.MarkAllRead(userID)
+}
+
+// userLocation mengembalikan zona waktu user, pesan notifikasi tersimpan sebagai teks
+// sehingga waktunya harus sudah dalam zona user saat dibuat.
+func (n *notificationService) userLocation(userID uint) *time.Location {
+	user, err := n.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return time.UTC
+	}
+
+	return utils.LocationOrUTC(user.Timezone)
+}
+
+// NotifyUpcomingDeadlines implements services.NotificationService.
+// Setiap task hanya mendapat satu reminder, walaupun job berjalan berkali-kali.
+func (n *notificationService) NotifyUpcomingDeadlines(window time.Duration) error {
+	now := time.Now()
+
+	tasks, err := n.taskRepo.GetDueBetween(now, now.Add(window))
+	if err != nil {
+		return err
+	}
+
+	// task yang gagal dicatat di log dan dicoba lagi di run berikutnya, task lain tetap mendapat reminder
+	for _, task := range tasks {
+		if err := n.notifyDeadline(&task); err != nil {
+			logger.Error("failed to send deadline reminder", zap.Uint("task_id", task.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+func (n *notificationService) notifyDeadline(task *domain.Task) error {
+	exists, err := n.notificationRepo.Exists(task.UserID, task.ID, domain.NotificationDeadlineApproach)
+	if err != nil || exists {
+		return err
+	}
+
+	taskID := task.ID
+	deadline := task.Deadline.In(n.userLocation(task.UserID))
+	message := fmt.Sprintf("Task %q is due on %s", task.Title, deadline.Format("2006-01-02 15:04 MST"))
+
+	return n.Notify(task.UserID, &taskID, domain.NotificationDeadlineApproach, message)
+}
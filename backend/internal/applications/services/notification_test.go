@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"testing"
+	"time"
+)
+
+// failingNotifications gagal menyimpan notifikasi untuk task failTaskID.
+type failingNotifications struct {
+	repository.NotificationRepository
+	failTaskID uint
+}
+
+func (f *failingNotifications) Create(notification *domain.Notification) error {
+	if notification.TaskID != nil && *notification.TaskID == f.failTaskID {
+		return errors.New("insert failed")
+	}
+
+	return f.NotificationRepository.Create(notification)
+}
+
+func TestNotifyUpcomingDeadlinesContinuesAfterFailure(t *testing.T) {
+	database := openTestDB(t)
+
+	tasks := storages.NewTaskRepository(database)
+	users := storages.NewUserRepository(database)
+	notifications := storages.NewNotificationRepository(database)
+
+	if err := users.Create(&domain.User{Name: "Alice", Username: "alice", Password: "hash"}); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	longTitle := strings.Repeat("a", 255)
+
+	for _, title := range []string{"fails", longTitle} {
+		if err := tasks.Create(&domain.Task{UserID: 1, CreatedBy: 1, Title: title, Status: domain.ToDo, Deadline: &deadline}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	service := NewNotificationService(&failingNotifications{NotificationRepository: notifications, failTaskID: 1},
+		storages.NewNotificationPreferenceRepository(database), tasks, users, nil)
+
+	if err := service.NotifyUpcomingDeadlines(24 * time.Hour); err != nil {
+		t.Fatalf("NotifyUpcomingDeadlines = %v", err)
+	}
+
+	inbox, total, err := notifications.GetByUser(1, false, 10, 0)
+	if err != nil || total != 1 {
+		t.Fatalf("reminders = %d, %v, want 1 after the failed task", total, err)
+	}
+
+	if *inbox[0].TaskID != 2 || !strings.Contains(inbox[0].Message, longTitle) {
+		t.Errorf("reminder = %+v, want the full title of task 2", inbox[0])
+	}
+}
+
+func TestNotificationEmailNeedsVerifiedAddress(t *testing.T) {
+	database := openTestDB(t)
+
+	tasks := storages.NewTaskRepository(database)
+	users := storages.NewUserRepository(database)
+	mails := newMailbox()
+	service := NewNotificationService(storages.NewNotificationRepository(database),
+		storages.NewNotificationPreferenceRepository(database), tasks, users, mails)
+
+	verified := time.Now()
+	for _, user := range []*domain.User{
+		{Name: "Alice", Username: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified},
+		{Name: "Bob", Username: "bob", Email: "bob@example.com"},
+	} {
+		if err := users.Create(user); err != nil {
+			t.Fatal(err)
+		}
+
+		task := &domain.Task{UserID: user.ID, CreatedBy: user.ID, Title: "Assigned", Status: domain.ToDo}
+		if err := tasks.Create(task); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := service.Notify(user.ID, &task.ID, domain.NotificationTaskAssigned, "assigned"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(mails.sent) != 1 || mails.sent[0] != "assigned:alice" {
+		t.Errorf("sent = %v, want only the verified address", mails.sent)
+	}
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// oidcStateTTL adalah batas waktu user menyelesaikan login di halaman provider.
+const oidcStateTTL = 10 * time.Minute
+
+var (
+	ErrInvalidOIDCState = errors.New("invalid or expired login state")
+	ErrOIDCLoginFailed  = errors.New("oidc login failed")
+)
+
+type oidcService struct {
+	provider     services.OIDCProvider
+	userRepo     repository.UserRepository
+	identityRepo repository.UserIdentityRepository
+	stateRepo    repository.OIDCLoginStateRepository
+	sessions     services.SessionService
+	uow          repository.UnitOfWork
+}
+
+func NewOIDCService(
+	provider services.OIDCProvider,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	stateRepo repository.OIDCLoginStateRepository,
+	sessions services.SessionService,
+	uow repository.UnitOfWork,
+) services.OIDCService {
+	return &oidcService{
+		provider:     provider,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		stateRepo:    stateRepo,
+		sessions:     sessions,
+		uow:          uow,
+	}
+}
+
+// BeginLogin implements services.OIDCService.
+func (s *oidcService) BeginLogin() (*domain.OIDCAuthorization, error) {
+	state, err := utils.GenerateToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := utils.GenerateToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := utils.GenerateToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	// PKCE S256: challenge = base64url(sha256(verifier))
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	url, err := s.provider.AuthCodeURL(state, nonce, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.stateRepo.Create(&domain.OIDCLoginState{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.OIDCAuthorization{URL: url, State: state}, nil
+}
+
+// CompleteLogin implements services.OIDCService.
+func (s *oidcService) CompleteLogin(state string, code string, client domain.ClientInfo) (*domain.LoginResult, error) {
+	loginState, err := s.stateRepo.Take(state)
+	if err != nil {
+		return nil, err
+	}
+
+	if loginState == nil || !loginState.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidOIDCState
+	}
+
+	identity, err := s.provider.Exchange(code, loginState.CodeVerifier)
+	if err != nil {
+		logger.Warn("oidc code exchange failed", zap.Error(err))
+		return nil, ErrOIDCLoginFailed
+	}
+
+	if identity.Nonce != loginState.Nonce {
+		logger.Warn("oidc nonce mismatch", zap.String("subject", identity.Subject))
+		return nil, ErrOIDCLoginFailed
+	}
+
+	user, err := s.findOrProvision(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, ErrUserDeactivated
+	}
+
+	token, err := s.sessions.Start(user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	return &domain.LoginResult{Token: token, User: user}, nil
+}
+
+// PruneLoginStates implements services.OIDCService.
+func (s *oidcService) PruneLoginStates() error {
+	return s.stateRepo.DeleteExpired(time.Now())
+}
+
+// oidcProvisionAttempts membatasi percobaan ulang saat username yang dipilih diambil login lain.
+const oidcProvisionAttempts = 3
+
+// findOrProvision mencari user yang sudah terhubung dengan subject ini, atau membuat user baru
+// saat login pertama. User lokal tidak dihubungkan otomatis lewat email supaya akun tidak bisa
+// diambil alih dari provider yang tidak memverifikasi email.
+//
+// User dan identity dibuat dalam satu unit of work sehingga tidak ada user tanpa identity. Jika
+// callback lain untuk subject yang sama menang lebih dulu, identity-nya dibaca ulang, dan jika
+// username-nya yang bentrok, username lain dicoba.
+func (s *oidcService) findOrProvision(identity *domain.OIDCIdentity) (*domain.User, error) {
+	for attempt := 0; attempt < oidcProvisionAttempts; attempt++ {
+		user, err := s.findLinked(identity)
+		if err != nil || user != nil {
+			return user, err
+		}
+
+		user, err = s.provision(identity)
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return user, err
+		}
+	}
+
+	return nil, ErrOIDCLoginFailed
+}
+
+// findLinked mengembalikan user yang terhubung dengan identity, nil jika belum ada.
+func (s *oidcService) findLinked(identity *domain.OIDCIdentity) (*domain.User, error) {
+	link, err := s.identityRepo.Find(identity.Issuer, identity.Subject)
+	if err != nil || link == nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(link.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrOIDCLoginFailed
+	}
+
+	return user, nil
+}
+
+func (s *oidcService) provision(identity *domain.OIDCIdentity) (*domain.User, error) {
+	username, err := s.uniqueUsername(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &domain.User{
+		Name:     identity.Name,
+		Username: username,
+		Role:     domain.RoleUser,
+	}
+
+	if user.Name == "" {
+		user.Name = username
+	}
+
+	if identity.EmailVerified && identity.Email != "" {
+		now := time.Now()
+		user.Email = identity.Email
+		user.EmailVerifiedAt = &now
+	}
+
+	err = s.uow.Do(func(repos repository.Repositories) error {
+		// user dari SSO tidak punya password lokal, login password selalu gagal
+		if err := repos.Users.Create(user); err != nil {
+			return err
+		}
+
+		return repos.UserIdentities.Create(&domain.UserIdentity{
+			UserID:  user.ID,
+			Issuer:  identity.Issuer,
+			Subject: identity.Subject,
+			Email:   identity.Email,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("provisioned user from oidc", zap.Uint("user_id", user.ID), zap.String("username", username))
+
+	return user, nil
+}
+
+// uniqueUsername membuat username dari preferred_username atau email, ditambah angka jika sudah dipakai.
+func (s *oidcService) uniqueUsername(identity *domain.OIDCIdentity) (string, error) {
+	base := identity.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	base = sanitizeUsername(base)
+	for domain.IsReservedUsername(base) {
+		base = base[len(domain.DeletedUsernamePrefix):]
+	}
+
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 2; i < 1000; i++ {
+		existing, err := s.userRepo.FindByUsername(candidate)
+		if err != nil {
+			return "", err
+		}
+
+		if existing == nil {
+			return candidate, nil
+		}
+
+		candidate = fmt.Sprintf("%s%d", base, i)
+	}
+
+	return "", fmt.Errorf("no free username for %s", base)
+}
+
+func sanitizeUsername(name string) string {
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+
+	username := b.String()
+	if len(username) > 90 {
+		username = username[:90]
+	}
+
+	return username
+}
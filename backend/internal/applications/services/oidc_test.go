@@ -0,0 +1,368 @@
+package services
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/oidc"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/security"
+	"task-management/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const testClientID = "task-management"
+
+// mockIssuer adalah OIDC provider minimal: discovery, JWKS, dan token endpoint yang memeriksa PKCE.
+type mockIssuer struct {
+	server *httptest.Server
+	key    *ecdsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]issuedCode
+}
+
+// issuedCode adalah authorization code yang sudah diberikan ke user beserta data dari request authorize.
+type issuedCode struct {
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &mockIssuer{key: key, codes: map[string]issuedCode{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		jwk, _ := security.NewJWK("mock", "ES256", &key.PublicKey)
+		writeJSON(w, http.StatusOK, domain.JWKSet{Keys: []domain.JWK{jwk}})
+	})
+	mux.HandleFunc("/token", issuer.token)
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// login meniru user yang login di halaman provider: mengambil parameter dari URL authorize
+// dan mengembalikan authorization code untuk claims.
+func (m *mockIssuer) login(t *testing.T, authorizeURL string, claims jwt.MapClaims) (state, code string) {
+	t.Helper()
+
+	parsed, err := url.Parse(authorizeURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		t.Fatalf("authorize URL without PKCE: %s", authorizeURL)
+	}
+
+	code, _ = utils.GenerateToken(16)
+
+	m.mu.Lock()
+	m.codes[code] = issuedCode{challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), claims: claims}
+	m.mu.Unlock()
+
+	return query.Get("state"), code
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	m.mu.Lock()
+	issued, ok := m.codes[r.Form.Get("code")]
+	delete(m.codes, r.Form.Get("code"))
+	m.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != issued.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   testClientID,
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": issued.nonce,
+	}
+	for name, value := range issued.claims {
+		claims[name] = value
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = "mock"
+
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id_token": signed})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+type oidcFixture struct {
+	service    *oidcService
+	issuer     *mockIssuer
+	users      repository.UserRepository
+	identities repository.UserIdentityRepository
+}
+
+func newTestOIDC(t *testing.T) *oidcFixture {
+	t.Helper()
+
+	database := openTestDB(t)
+	issuer := newMockIssuer(t)
+
+	f := &oidcFixture{
+		issuer:     issuer,
+		users:      storages.NewUserRepository(database),
+		identities: storages.NewUserIdentityRepository(database),
+	}
+
+	provider := oidc.NewProvider(config.OIDCConfig{
+		Issuer:      issuer.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost/auth/oidc/callback",
+	})
+
+	sessions := NewSessionService(storages.NewSessionRepository(database), security.NewJWTAdapter(security.NewHMACKeySet("secret"), time.Hour), time.Hour)
+	service := NewOIDCService(provider, f.users, f.identities, storages.NewOIDCLoginStateRepository(database), sessions, storages.NewUnitOfWork(database))
+
+	f.service = service.(*oidcService)
+	return f
+}
+
+// begin memulai login dan mengembalikan state serta code dari provider untuk claims.
+func (f *oidcFixture) begin(t *testing.T, claims jwt.MapClaims) (string, string) {
+	t.Helper()
+
+	authorization, err := f.service.BeginLogin()
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+
+	state, code := f.issuer.login(t, authorization.URL, claims)
+	if state != authorization.State {
+		t.Fatalf("state in authorize URL = %q, want %q", state, authorization.State)
+	}
+
+	return state, code
+}
+
+func (f *oidcFixture) userCount(t *testing.T) int64 {
+	t.Helper()
+
+	_, total, err := f.users.Search("", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
+
+var oidcClient = domain.ClientInfo{UserAgent: "test", IP: "10.0.0.1"}
+
+func TestOIDCFirstLoginProvisionsUser(t *testing.T) {
+	f := newTestOIDC(t)
+	claims := jwt.MapClaims{"sub": "subject-1", "preferred_username": "Alice", "email": "alice@example.com", "email_verified": true}
+
+	state, code := f.begin(t, claims)
+	result, err := f.service.CompleteLogin(state, code, oidcClient)
+	if err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+
+	if result.Token == "" || result.User.Username != "alice" || result.User.EmailVerifiedAt == nil || result.User.Password != "" {
+		t.Errorf("provisioned user = %+v", result.User)
+	}
+
+	link, err := f.identities.Find(f.issuer.server.URL, "subject-1")
+	if err != nil || link == nil || link.UserID != result.User.ID {
+		t.Fatalf("identity = %+v, %v, want linked to user %d", link, err, result.User.ID)
+	}
+
+	// login berikutnya memakai user yang sama
+	state, code = f.begin(t, claims)
+	again, err := f.service.CompleteLogin(state, code, oidcClient)
+	if err != nil || again.User.ID != result.User.ID {
+		t.Fatalf("second login = %+v, %v, want user %d", again, err, result.User.ID)
+	}
+
+	if total := f.userCount(t); total != 1 {
+		t.Errorf("users = %d, want 1", total)
+	}
+}
+
+func TestOIDCLoginUsesLinkedAccount(t *testing.T) {
+	f := newTestOIDC(t)
+
+	existing := &domain.User{Name: "Alice", Username: "alice", Email: "alice@example.com", Password: "hash"}
+	if err := f.users.Create(existing); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := f.identities.Create(&domain.UserIdentity{UserID: existing.ID, Issuer: f.issuer.server.URL, Subject: "subject-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	state, code := f.begin(t, jwt.MapClaims{"sub": "subject-1", "preferred_username": "someone-else"})
+	result, err := f.service.CompleteLogin(state, code, oidcClient)
+	if err != nil || result.User.ID != existing.ID {
+		t.Fatalf("login with linked identity = %+v, %v, want user %d", result, err, existing.ID)
+	}
+
+	// subject lain dengan email yang sama tidak dihubungkan otomatis ke akun lokal
+	state, code = f.begin(t, jwt.MapClaims{"sub": "subject-2", "preferred_username": "alice", "email": "alice@example.com", "email_verified": true})
+	other, err := f.service.CompleteLogin(state, code, oidcClient)
+	if err != nil || other.User.ID == existing.ID || other.User.Username != "alice2" {
+		t.Fatalf("login with unlinked subject = %+v, %v, want a new user alice2", other, err)
+	}
+}
+
+func TestOIDCRejectsInvalidState(t *testing.T) {
+	f := newTestOIDC(t)
+	claims := jwt.MapClaims{"sub": "subject-1"}
+
+	_, code := f.begin(t, claims)
+	if _, err := f.service.CompleteLogin("unknown", code, oidcClient); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Errorf("unknown state = %v, want ErrInvalidOIDCState", err)
+	}
+
+	state, code := f.begin(t, claims)
+	if _, err := f.service.CompleteLogin(state, code, oidcClient); err != nil {
+		t.Fatalf("CompleteLogin: %v", err)
+	}
+
+	// state hanya bisa dipakai sekali
+	if _, err := f.service.CompleteLogin(state, code, oidcClient); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Errorf("reused state = %v, want ErrInvalidOIDCState", err)
+	}
+}
+
+func TestOIDCRejectsCodeFromAnotherLogin(t *testing.T) {
+	f := newTestOIDC(t)
+	claims := jwt.MapClaims{"sub": "subject-1"}
+
+	// code milik login lain punya code_challenge yang tidak cocok dengan verifier state ini
+	state, _ := f.begin(t, claims)
+	_, otherCode := f.begin(t, claims)
+
+	if _, err := f.service.CompleteLogin(state, otherCode, oidcClient); !errors.Is(err, ErrOIDCLoginFailed) {
+		t.Errorf("code with a wrong PKCE verifier = %v, want ErrOIDCLoginFailed", err)
+	}
+
+	if total := f.userCount(t); total != 0 {
+		t.Errorf("users = %d, want 0", total)
+	}
+}
+
+func TestOIDCConcurrentFirstLoginCreatesOneUser(t *testing.T) {
+	f := newTestOIDC(t)
+	claims := jwt.MapClaims{"sub": "subject-1", "preferred_username": "alice"}
+
+	type login struct{ state, code string }
+	logins := make([]login, 4)
+	for i := range logins {
+		logins[i].state, logins[i].code = f.begin(t, claims)
+	}
+
+	ids := make([]uint, len(logins))
+	errs := make([]error, len(logins))
+
+	var wg sync.WaitGroup
+	for i, l := range logins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := f.service.CompleteLogin(l.state, l.code, oidcClient)
+			errs[i] = err
+			if err == nil {
+				ids[i] = result.User.ID
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range logins {
+		if errs[i] != nil || ids[i] != ids[0] {
+			t.Errorf("login %d = user %d, %v, want user %d", i, ids[i], errs[i], ids[0])
+		}
+	}
+
+	if total := f.userCount(t); total != 1 {
+		t.Errorf("users = %d, want 1 without orphans", total)
+	}
+}
+
+// identity yang gagal disimpan membatalkan user yang baru dibuat
+func TestOIDCProvisionRollsBackUserWhenIdentityExists(t *testing.T) {
+	f := newTestOIDC(t)
+
+	owner := &domain.User{Name: "Owner", Username: "owner"}
+	if err := f.users.Create(owner); err != nil {
+		t.Fatal(err)
+	}
+
+	identity := &domain.OIDCIdentity{Issuer: f.issuer.server.URL, Subject: "subject-1", PreferredUsername: "alice"}
+	if err := f.identities.Create(&domain.UserIdentity{UserID: owner.ID, Issuer: identity.Issuer, Subject: identity.Subject}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.service.provision(identity); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("provision with a linked subject = %v, want gorm.ErrDuplicatedKey", err)
+	}
+
+	if alice, _ := f.users.FindByUsername("alice"); alice != nil {
+		t.Error("user without identity was kept")
+	}
+
+	user, err := f.service.findOrProvision(identity)
+	if err != nil || user.ID != owner.ID {
+		t.Errorf("findOrProvision = %+v, %v, want user %d", user, err, owner.ID)
+	}
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+type passwordService struct {
+	userRepo  repository.UserRepository
+	resetRepo repository.PasswordResetTokenRepository
+	sessions  services.SessionService
+	notifier  services.Notifier
+	policy    domain.PasswordPolicy
+	resetTTL  time.Duration
+	resetURL  string
+}
+
+// NewPasswordService membuat service ganti dan reset password. Jika resetURL diisi,
+// email reset berisi link resetURL?token=... selain itu hanya token.
+func NewPasswordService(
+	userRepo repository.UserRepository,
+	resetRepo repository.PasswordResetTokenRepository,
+	sessions services.SessionService,
+	notifier services.Notifier,
+	policy domain.PasswordPolicy,
+	resetTTL time.Duration,
+	resetURL string,
+) services.PasswordService {
+	return &passwordService{
+		userRepo:  userRepo,
+		resetRepo: resetRepo,
+		sessions:  sessions,
+		notifier:  notifier,
+		policy:    policy,
+		resetTTL:  resetTTL,
+		resetURL:  resetURL,
+	}
+}
+
+// ChangePassword implements services.PasswordService.
+func (s *passwordService) ChangePassword(userID uint, currentPassword string, newPassword string) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	if utils.CheckPassword(user.Password, currentPassword) != nil {
+		return ErrInvalidPassword
+	}
+
+	return s.setPassword(user, newPassword)
+}
+
+// ForcePassword implements services.PasswordService.
+func (s *passwordService) ForcePassword(userID uint, newPassword string) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return errors.New("user not found")
+	}
+
+	return s.setPassword(user, newPassword)
+}
+
+// RequestReset implements services.PasswordService.
+func (s *passwordService) RequestReset(username string) error {
+	user, err := s.userRepo.FindByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	if user == nil || !user.HasVerifiedEmail() {
+		logger.Info("password reset skipped, no user or verified email", zap.String("username", username))
+		return nil
+	}
+
+	plain, err := utils.GenerateToken(32)
+	if err != nil {
+		return err
+	}
+
+	// hanya token terakhir yang berlaku
+	if err := s.resetRepo.DeleteByUser(user.ID); err != nil {
+		return err
+	}
+
+	token := &domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(plain),
+		ExpiresAt: time.Now().Add(s.resetTTL),
+	}
+
+	if err := s.resetRepo.Create(token); err != nil {
+		return err
+	}
+
+	reset := &domain.PasswordReset{
+		Token:     plain,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	if s.resetURL != "" {
+		reset.URL = s.resetURL + "?token=" + url.QueryEscape(plain)
+	}
+
+	if err := s.notifier.SendPasswordReset(user, reset); err != nil {
+		logger.Error("failed to send password reset email", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// ResetPassword implements services.PasswordService.
+func (s *passwordService) ResetPassword(plain string, newPassword string) error {
+	token, err := s.resetRepo.FindByHash(utils.HashToken(plain))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if token == nil || token.UsedAt != nil || !token.ExpiresAt.After(now) {
+		return ErrInvalidResetToken
+	}
+
+	// cek policy sebelum token dipakai supaya user bisa mencoba password lain dengan token yang sama
+	if err := s.policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	used, err := s.resetRepo.MarkUsed(token.ID, now)
+	if err != nil {
+		return err
+	}
+
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.setPassword(user, newPassword); err != nil {
+		return err
+	}
+
+	return s.resetRepo.DeleteByUser(user.ID)
+}
+
+// setPassword menyimpan password baru lalu mencabut semua session user.
+func (s *passwordService) setPassword(user *domain.User, newPassword string) error {
+	if err := s.policy.Validate(newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	user.Password = hashed
+	user.PasswordChangedAt = &now
+
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.sessions.RevokeAll(user.ID)
+}
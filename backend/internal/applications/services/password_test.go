@@ -0,0 +1,235 @@
+package services
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/utils"
+	"testing"
+	"time"
+)
+
+// mailbox mencatat email yang dikirim sebagai "jenis:username" dan email reset terakhir per user.
+type mailbox struct {
+	sent         []string
+	resets       map[uint]*domain.PasswordReset
+	verification *domain.EmailVerification
+	digests      []*domain.Digest
+}
+
+func newMailbox() *mailbox {
+	return &mailbox{resets: map[uint]*domain.PasswordReset{}}
+}
+
+func (m *mailbox) SendTaskAssigned(user *domain.User, task *domain.Task) error {
+	m.sent = append(m.sent, "assigned:"+user.Username)
+	return nil
+}
+
+func (m *mailbox) SendDeadlineReminder(user *domain.User, task *domain.Task) error {
+	m.sent = append(m.sent, "deadline:"+user.Username)
+	return nil
+}
+
+func (m *mailbox) SendDigest(user *domain.User, digest *domain.Digest) error {
+	m.sent = append(m.sent, "digest:"+user.Username)
+	m.digests = append(m.digests, digest)
+	return nil
+}
+
+func (m *mailbox) SendEmailVerification(user *domain.User, verification *domain.EmailVerification) error {
+	m.sent = append(m.sent, "verify:"+verification.Email)
+	m.verification = verification
+	return nil
+}
+
+func (m *mailbox) SendPasswordReset(user *domain.User, reset *domain.PasswordReset) error {
+	m.sent = append(m.sent, "reset:"+user.Username)
+	m.resets[user.ID] = reset
+	return nil
+}
+
+// revokeCounter mencatat RevokeAll, method lain tidak dipakai password service.
+type revokeCounter struct {
+	revoked map[uint]int
+}
+
+func (r *revokeCounter) Start(user *domain.User, client domain.ClientInfo) (string, error) {
+	return "", nil
+}
+
+func (r *revokeCounter) List(userID uint) ([]domain.Session, error) { return nil, nil }
+
+func (r *revokeCounter) Revoke(userID uint, sessionID string) error { return nil }
+
+func (r *revokeCounter) RevokeAll(userID uint) error {
+	r.revoked[userID]++
+	return nil
+}
+
+func (r *revokeCounter) Check(claims *domain.JWTClaims) error { return nil }
+
+func (r *revokeCounter) PruneExpired() error { return nil }
+
+type passwordFixture struct {
+	service  *passwordService
+	users    repository.UserRepository
+	resets   repository.PasswordResetTokenRepository
+	mailbox  *mailbox
+	sessions *revokeCounter
+	user     *domain.User
+}
+
+func newTestPasswordService(t *testing.T) *passwordFixture {
+	t.Helper()
+
+	database := openTestDB(t)
+	f := &passwordFixture{
+		users:    storages.NewUserRepository(database),
+		resets:   storages.NewPasswordResetTokenRepository(database),
+		mailbox:  newMailbox(),
+		sessions: &revokeCounter{revoked: map[uint]int{}},
+	}
+
+	hashed, err := utils.HashPassword("old password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verified := time.Now()
+	f.user = &domain.User{Name: "Alice", Username: "alice", Email: "alice@example.com", EmailVerifiedAt: &verified, Password: hashed}
+	if err := f.users.Create(f.user); err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewPasswordService(f.users, f.resets, f.sessions, f.mailbox, domain.PasswordPolicy{MinLength: 10}, time.Hour, "https://app.example.com/reset")
+	f.service = service.(*passwordService)
+	return f
+}
+
+func (f *passwordFixture) requestReset(t *testing.T) string {
+	t.Helper()
+
+	if err := f.service.RequestReset("alice"); err != nil {
+		t.Fatalf("RequestReset: %v", err)
+	}
+
+	reset := f.mailbox.resets[f.user.ID]
+	if reset == nil {
+		t.Fatal("no reset email sent")
+	}
+
+	return reset.Token
+}
+
+func (f *passwordFixture) passwordIs(t *testing.T, password string) bool {
+	t.Helper()
+
+	user, err := f.users.FindByID(f.user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return utils.CheckPassword(user.Password, password) == nil
+}
+
+func TestResetPasswordTokenIsSingleUse(t *testing.T) {
+	f := newTestPasswordService(t)
+	token := f.requestReset(t)
+
+	if url := f.mailbox.resets[f.user.ID].URL; url != "https://app.example.com/reset?token="+token {
+		t.Errorf("reset URL = %q", url)
+	}
+
+	// token hanya disimpan sebagai hash
+	if stored, err := f.resets.FindByHash(token); err != nil || stored != nil {
+		t.Errorf("plain token found in storage = %+v, %v", stored, err)
+	}
+
+	if err := f.service.ResetPassword(token, "new password 1"); err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+
+	if !f.passwordIs(t, "new password 1") {
+		t.Error("password not changed")
+	}
+
+	if f.sessions.revoked[f.user.ID] != 1 {
+		t.Errorf("sessions revoked %d times, want 1", f.sessions.revoked[f.user.ID])
+	}
+
+	if err := f.service.ResetPassword(token, "new password 2"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("second use = %v, want ErrInvalidResetToken", err)
+	}
+
+	if !f.passwordIs(t, "new password 1") {
+		t.Error("used token changed the password again")
+	}
+}
+
+func TestResetPasswordOnlyLatestTokenIsValid(t *testing.T) {
+	f := newTestPasswordService(t)
+	first := f.requestReset(t)
+	second := f.requestReset(t)
+
+	if err := f.service.ResetPassword(first, "new password 1"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("older token = %v, want ErrInvalidResetToken", err)
+	}
+
+	if err := f.service.ResetPassword(second, "new password 1"); err != nil {
+		t.Errorf("latest token = %v", err)
+	}
+}
+
+func TestResetPasswordRejectsExpiredToken(t *testing.T) {
+	f := newTestPasswordService(t)
+	f.service.resetTTL = -time.Minute
+	token := f.requestReset(t)
+
+	if err := f.service.ResetPassword(token, "new password 1"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Errorf("expired token = %v, want ErrInvalidResetToken", err)
+	}
+
+	if !f.passwordIs(t, "old password") {
+		t.Error("expired token changed the password")
+	}
+}
+
+func TestResetPasswordPolicyFailureKeepsToken(t *testing.T) {
+	f := newTestPasswordService(t)
+	token := f.requestReset(t)
+
+	var policyErr *domain.PasswordPolicyError
+	if err := f.service.ResetPassword(token, "short"); !errors.As(err, &policyErr) {
+		t.Fatalf("weak password = %v, want PasswordPolicyError", err)
+	}
+
+	if err := f.service.ResetPassword(token, "new password 1"); err != nil {
+		t.Errorf("retry with the same token = %v", err)
+	}
+}
+
+func TestRequestResetNeedsVerifiedEmail(t *testing.T) {
+	f := newTestPasswordService(t)
+
+	for _, user := range []*domain.User{
+		{Name: "Bob", Username: "bob"},
+		{Name: "Carol", Username: "carol", Email: "carol@example.com"},
+	} {
+		if err := f.users.Create(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// carol belum mengonfirmasi email, reset tidak boleh dikirim ke alamat yang belum tentu miliknya
+	for _, username := range []string{"nobody", "bob", "carol"} {
+		if err := f.service.RequestReset(username); err != nil {
+			t.Errorf("RequestReset(%q) = %v, want nil", username, err)
+		}
+	}
+
+	if len(f.mailbox.resets) != 0 {
+		t.Errorf("reset emails sent = %v, want none", f.mailbox.resets)
+	}
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrNameRequired             = errors.New("name is required")
+	ErrInvalidEmail             = errors.New("invalid email address")
+	ErrInvalidTimezone          = errors.New("invalid timezone")
+	ErrInvalidLocale            = errors.New("invalid locale")
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrAvatarNotFound           = errors.New("avatar not found")
+	ErrInvalidAvatarSize        = errors.New("invalid avatar size")
+)
+
+// avatarContentType adalah format semua ukuran avatar yang disimpan.
+const avatarContentType = "image/png"
+
+type profileService struct {
+	userRepo   repository.UserRepository
+	verifyRepo repository.EmailVerificationTokenRepository
+	notifier   services.Notifier
+	blobs      services.BlobStorage
+	resizer    services.ImageResizer
+	verifyTTL  time.Duration
+	verifyURL  string
+}
+
+// NewProfileService membuat service profil user. Jika verifyURL diisi, email verifikasi
+// berisi link verifyURL?token=... selain itu hanya token.
+func NewProfileService(
+	userRepo repository.UserRepository,
+	verifyRepo repository.EmailVerificationTokenRepository,
+	notifier services.Notifier,
+	blobs services.BlobStorage,
+	resizer services.ImageResizer,
+	verifyTTL time.Duration,
+	verifyURL string,
+) services.ProfileService {
+	return &profileService{
+		userRepo:   userRepo,
+		verifyRepo: verifyRepo,
+		notifier:   notifier,
+		blobs:      blobs,
+		resizer:    resizer,
+		verifyTTL:  verifyTTL,
+		verifyURL:  verifyURL,
+	}
+}
+
+// UpdateProfile implements services.ProfileService.
+func (s *profileService) UpdateProfile(userID uint, update domain.ProfileUpdate) (*domain.User, string, error) {
+	user, err := s.find(userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, "", ErrNameRequired
+		}
+
+		user.Name = name
+	}
+
+	if update.Timezone != nil {
+		// nama zona divalidasi ke database zona waktu, kosong berarti UTC
+		if _, err := utils.LoadLocation(*update.Timezone); err != nil {
+			return nil, "", ErrInvalidTimezone
+		}
+
+		user.Timezone = *update.Timezone
+	}
+
+	if update.Locale != nil {
+		if *update.Locale != "" && !domain.IsValidLocale(*update.Locale) {
+			return nil, "", ErrInvalidLocale
+		}
+
+		user.Locale = *update.Locale
+	}
+
+	var pendingEmail string
+
+	if update.Email != nil {
+		email := strings.TrimSpace(*update.Email)
+
+		if email != "" {
+			addr, err := mail.ParseAddress(email)
+			if err != nil || addr.Address != email {
+				return nil, "", ErrInvalidEmail
+			}
+		}
+
+		switch {
+		case email == "":
+			user.Email = ""
+			user.EmailVerifiedAt = nil
+
+			if err := s.verifyRepo.DeleteByUser(user.ID); err != nil {
+				return nil, "", err
+			}
+		case !strings.EqualFold(email, user.Email) || user.EmailVerifiedAt == nil:
+			pendingEmail = email
+		}
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, "", err
+	}
+
+	if pendingEmail != "" {
+		if err := s.SendVerification(user, pendingEmail); err != nil {
+			return nil, "", err
+		}
+	}
+
+	user.Password = ""
+	return user, pendingEmail, nil
+}
+
+// SendVerification implements services.EmailVerifier.
+// Hanya token terakhir yang berlaku.
+func (s *profileService) SendVerification(user *domain.User, email string) error {
+	plain, err := utils.GenerateToken(32)
+	if err != nil {
+		return err
+	}
+
+	if err := s.verifyRepo.DeleteByUser(user.ID); err != nil {
+		return err
+	}
+
+	token := &domain.EmailVerificationToken{
+		UserID:    user.ID,
+		Email:     email,
+		TokenHash: utils.HashToken(plain),
+		ExpiresAt: time.Now().Add(s.verifyTTL),
+	}
+
+	if err := s.verifyRepo.Create(token); err != nil {
+		return err
+	}
+
+	verification := &domain.EmailVerification{
+		Email:     email,
+		Token:     plain,
+		ExpiresAt: token.ExpiresAt,
+	}
+
+	if s.verifyURL != "" {
+		verification.URL = s.verifyURL + "?token=" + url.QueryEscape(plain)
+	}
+
+	if err := s.notifier.SendEmailVerification(user, verification); err != nil {
+		logger.Error("failed to send email verification", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+
+	return nil
+}
+
+// VerifyEmail implements services.ProfileService.
+func (s *profileService) VerifyEmail(plain string) error {
+	token, err := s.verifyRepo.Take(utils.HashToken(plain))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if token == nil || !token.ExpiresAt.After(now) {
+		return ErrInvalidVerificationToken
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrInvalidVerificationToken
+	}
+
+	user.Email = token.Email
+	user.EmailVerifiedAt = &now
+
+	return s.userRepo.Update(user)
+}
+
+// SetAvatar implements services.ProfileService.
+func (s *profileService) SetAvatar(userID uint, image []byte) (*domain.User, error) {
+	user, err := s.find(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resized, err := s.resizer.ResizeSquare(image, domain.AvatarSizes)
+	if err != nil {
+		return nil, err
+	}
+
+	for size, data := range resized {
+		if err := s.blobs.Put(avatarKey(user.ID, size), data); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	user.AvatarUpdatedAt = &now
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+// Avatar implements services.ProfileService.
+func (s *profileService) Avatar(userID uint, size int) (*domain.Avatar, error) {
+	if !domain.IsAvatarSize(size) {
+		return nil, ErrInvalidAvatarSize
+	}
+
+	user, err := s.find(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.AvatarUpdatedAt == nil {
+		return nil, ErrAvatarNotFound
+	}
+
+	data, err := s.blobs.Get(avatarKey(user.ID, size))
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, ErrAvatarNotFound
+	}
+
+	return &domain.Avatar{
+		Data:        data,
+		ContentType: avatarContentType,
+		UpdatedAt:   *user.AvatarUpdatedAt,
+	}, nil
+}
+
+// DeleteAvatar implements services.ProfileService.
+func (s *profileService) DeleteAvatar(userID uint) (*domain.User, error) {
+	user, err := s.find(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := deleteAvatarBlobs(s.blobs, user.ID); err != nil {
+		return nil, err
+	}
+
+	user.AvatarUpdatedAt = nil
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	user.Password = ""
+	return user, nil
+}
+
+func (s *profileService) find(userID uint) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func avatarKey(userID uint, size int) string {
+	return fmt.Sprintf("avatars/%d/%d.png", userID, size)
+}
+
+// deleteAvatarBlobs menghapus semua ukuran avatar user, dipakai juga saat akun dihapus.
+func deleteAvatarBlobs(blobs services.BlobStorage, userID uint) error {
+	for _, size := range domain.AvatarSizes {
+		if err := blobs.Delete(avatarKey(userID, size)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/blob"
+	"task-management/internal/infra/adapter/imaging"
+	"task-management/internal/infra/adapter/storages"
+	"testing"
+	"time"
+)
+
+func newTestProfileService(t *testing.T) (services.ProfileService, repository.UserRepository, services.BlobStorage, *mailbox) {
+	t.Helper()
+
+	database := openTestDB(t)
+	users := storages.NewUserRepository(database)
+	blobs := blob.NewMemoryStorage()
+	mails := newMailbox()
+
+	profiles := NewProfileService(users, storages.NewEmailVerificationTokenRepository(database), mails, blobs, imaging.NewResizer(), time.Hour, "")
+	return profiles, users, blobs, mails
+}
+
+func createProfileUser(t *testing.T, users repository.UserRepository, email string, verified bool) *domain.User {
+	t.Helper()
+
+	user := &domain.User{Name: "Alice", Username: "alice", Password: "x", Email: email}
+	if verified {
+		verifiedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		user.EmailVerifiedAt = &verifiedAt
+	}
+
+	if err := users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestSetAvatarStoresEverySize(t *testing.T) {
+	profiles, users, _, _ := newTestProfileService(t)
+	alice := createProfileUser(t, users, "", false)
+
+	updated, err := profiles.SetAvatar(alice.ID, encodePNG(t, 300, 200))
+	if err != nil {
+		t.Fatalf("SetAvatar: %v", err)
+	}
+
+	if updated.AvatarUpdatedAt == nil {
+		t.Fatal("AvatarUpdatedAt not set")
+	}
+
+	for _, size := range domain.AvatarSizes {
+		avatar, err := profiles.Avatar(alice.ID, size)
+		if err != nil {
+			t.Fatalf("Avatar(%d): %v", size, err)
+		}
+
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(avatar.Data))
+		if err != nil || format != "png" || avatar.ContentType != "image/png" {
+			t.Fatalf("Avatar(%d) = %s %q, %v", size, format, avatar.ContentType, err)
+		}
+
+		if cfg.Width != size || cfg.Height != size {
+			t.Errorf("Avatar(%d) is %dx%d", size, cfg.Width, cfg.Height)
+		}
+	}
+
+	if _, err := profiles.Avatar(alice.ID, 100); err != ErrInvalidAvatarSize {
+		t.Errorf("Avatar(100) = %v, want invalid avatar size", err)
+	}
+}
+
+func TestSetAvatarRejectsNonImage(t *testing.T) {
+	profiles, users, blobs, _ := newTestProfileService(t)
+	alice := createProfileUser(t, users, "", false)
+
+	for name, data := range map[string][]byte{
+		"text":      []byte("definitely not an image"),
+		"truncated": encodePNG(t, 64, 64)[:100],
+	} {
+		if _, err := profiles.SetAvatar(alice.ID, data); err == nil || err.Error() != "unsupported or invalid image" {
+			t.Errorf("%s: SetAvatar = %v, want unsupported or invalid image", name, err)
+		}
+	}
+
+	stored, _ := users.FindByID(alice.ID)
+	if stored.AvatarUpdatedAt != nil {
+		t.Error("AvatarUpdatedAt set for a rejected upload")
+	}
+
+	for _, size := range domain.AvatarSizes {
+		if data, _ := blobs.Get(avatarKey(alice.ID, size)); data != nil {
+			t.Errorf("size %d stored for a rejected upload", size)
+		}
+	}
+}
+
+// alamat baru belum dianggap terverifikasi sampai token dipakai, dan EmailVerifiedAt
+// selalu milik alamat yang tersimpan
+func TestEmailChangeIsVerifiedOnlyAfterConfirmation(t *testing.T) {
+	profiles, users, _, mails := newTestProfileService(t)
+	alice := createProfileUser(t, users, "old@example.com", true)
+	oldVerifiedAt := *alice.EmailVerifiedAt
+
+	email := "new@example.com"
+	updated, pending, err := profiles.UpdateProfile(alice.ID, domain.ProfileUpdate{Email: &email})
+	if err != nil {
+		t.Fatalf("UpdateProfile: %v", err)
+	}
+
+	if pending != email || mails.verification == nil || mails.verification.Email != email {
+		t.Fatalf("pending = %q, verification = %+v, want a link to %s", pending, mails.verification, email)
+	}
+
+	stored, _ := users.FindByID(alice.ID)
+	for _, user := range []*domain.User{updated, stored} {
+		if user.Email != "old@example.com" || user.EmailVerifiedAt == nil || !user.EmailVerifiedAt.Equal(oldVerifiedAt) {
+			t.Fatalf("before confirmation: email %q verified at %v, want the old verified address", user.Email, user.EmailVerifiedAt)
+		}
+	}
+
+	if err := profiles.VerifyEmail(mails.verification.Token); err != nil {
+		t.Fatalf("VerifyEmail: %v", err)
+	}
+
+	stored, _ = users.FindByID(alice.ID)
+	if stored.Email != email || stored.EmailVerifiedAt == nil || !stored.EmailVerifiedAt.After(oldVerifiedAt) {
+		t.Fatalf("after confirmation: email %q verified at %v, want %s verified now", stored.Email, stored.EmailVerifiedAt, email)
+	}
+
+	// token hanya bisa dipakai sekali
+	if err := profiles.VerifyEmail(mails.verification.Token); err != ErrInvalidVerificationToken {
+		t.Errorf("reused token: %v, want invalid verification token", err)
+	}
+}
+
+func TestEmailRemovalResetsVerification(t *testing.T) {
+	profiles, users, _, mails := newTestProfileService(t)
+	alice := createProfileUser(t, users, "old@example.com", false)
+
+	email := "new@example.com"
+	if _, _, err := profiles.UpdateProfile(alice.ID, domain.ProfileUpdate{Email: &email}); err != nil {
+		t.Fatal(err)
+	}
+
+	token := mails.verification.Token
+
+	empty := ""
+	updated, pending, err := profiles.UpdateProfile(alice.ID, domain.ProfileUpdate{Email: &empty})
+	if err != nil || pending != "" {
+		t.Fatalf("UpdateProfile = %q, %v", pending, err)
+	}
+
+	if updated.Email != "" || updated.EmailVerifiedAt != nil || updated.HasVerifiedEmail() {
+		t.Errorf("email = %q, verified at %v, want both cleared", updated.Email, updated.EmailVerifiedAt)
+	}
+
+	// link yang masih beredar tidak boleh memasang alamat yang sudah dihapus
+	if err := profiles.VerifyEmail(token); err != ErrInvalidVerificationToken {
+		t.Errorf("pending token after removal: %v, want invalid verification token", err)
+	}
+}
+
+func TestUpdateProfileValidation(t *testing.T) {
+	profiles, users, _, mails := newTestProfileService(t)
+	alice := createProfileUser(t, users, "alice@example.com", true)
+
+	blank, badEmail, badZone, badLocale := "  ", "Alice <alice@example.com>", "Mars/Olympus", "english!"
+	sameEmail := "alice@example.com"
+
+	tests := []struct {
+		name   string
+		update domain.ProfileUpdate
+		want   error
+	}{
+		{"blank name", domain.ProfileUpdate{Name: &blank}, ErrNameRequired},
+		{"email with display name", domain.ProfileUpdate{Email: &badEmail}, ErrInvalidEmail},
+		{"unknown time zone", domain.ProfileUpdate{Timezone: &badZone}, ErrInvalidTimezone},
+		{"unknown locale", domain.ProfileUpdate{Locale: &badLocale}, ErrInvalidLocale},
+		{"same verified email", domain.ProfileUpdate{Email: &sameEmail}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := profiles.UpdateProfile(alice.ID, tt.update); err != tt.want {
+				t.Fatalf("UpdateProfile = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if len(mails.sent) != 0 {
+		t.Errorf("sent = %v, want no verification for the current address", mails.sent)
+	}
+}
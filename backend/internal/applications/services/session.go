@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// maxUserAgentLength mengikuti ukuran kolom user_agent.
+const maxUserAgentLength = 255
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionRevoked  = errors.New("session has been revoked")
+)
+
+type sessionService struct {
+	repo repository.SessionRepository
+	jwt  services.JWTService
+	ttl  time.Duration
+}
+
+// NewSessionService membuat service session. ttl harus sama dengan umur access token.
+func NewSessionService(repo repository.SessionRepository, jwt services.JWTService, ttl time.Duration) services.SessionService {
+	return &sessionService{
+		repo: repo,
+		jwt:  jwt,
+		ttl:  ttl,
+	}
+}
+
+// truncateUserAgent memotong user agent ke maxUserAgentLength byte tanpa memotong karakter
+// multi-byte. Byte UTF-8 yang tidak valid diganti karena Postgres menolak teks seperti itu.
+func truncateUserAgent(userAgent string) string {
+	userAgent = strings.ToValidUTF8(userAgent, "\uFFFD")
+	if len(userAgent) <= maxUserAgentLength {
+		return userAgent
+	}
+
+	cut := maxUserAgentLength
+	for cut > 0 && !utf8.RuneStart(userAgent[cut]) {
+		cut--
+	}
+
+	return userAgent[:cut]
+}
+
+// Start implements services.SessionService.
+func (s *sessionService) Start(user *domain.User, client domain.ClientInfo) (string, error) {
+	id, err := utils.GenerateToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	userAgent := truncateUserAgent(client.UserAgent)
+
+	now := time.Now()
+
+	session := &domain.Session{
+		ID:         id,
+		UserID:     user.ID,
+		UserAgent:  userAgent,
+		IP:         client.IP,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(s.ttl),
+	}
+
+	if err := s.repo.Create(session); err != nil {
+		return "", err
+	}
+
+	return s.jwt.GenerateToken(user, session.ID)
+}
+
+// List implements services.SessionService.
+func (s *sessionService) List(userID uint) ([]domain.Session, error) {
+	return s.repo.ListActiveByUser(userID, time.Now())
+}
+
+// Revoke implements services.SessionService.
+func (s *sessionService) Revoke(userID uint, sessionID string) error {
+	session, err := s.repo.FindByID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return s.repo.Revoke(session.ID, time.Now())
+}
+
+// RevokeAll implements services.SessionService.
+func (s *sessionService) RevokeAll(userID uint) error {
+	return s.repo.RevokeAllByUser(userID, time.Now())
+}
+
+// Check implements services.SessionService.
+func (s *sessionService) Check(claims *domain.JWTClaims) error {
+	// token tanpa jti tidak terikat session sehingga tidak bisa dicabut, user harus login ulang
+	if claims.ID == "" {
+		return ErrSessionRevoked
+	}
+
+	session, err := s.repo.FindByID(claims.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if session == nil || session.UserID != claims.UserID || !session.IsActive(now) {
+		return ErrSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) > lastUsedInterval {
+		if err := s.repo.TouchLastSeen(session.ID, now); err != nil {
+			logger.Warn("failed to update session last seen", zap.String("session_id", session.ID), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// PruneExpired implements services.SessionService.
+func (s *sessionService) PruneExpired() error {
+	return s.repo.DeleteExpired(time.Now())
+}
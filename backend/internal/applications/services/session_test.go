@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/security"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestTruncateUserAgent(t *testing.T) {
+	// 254 byte ASCII diikuti karakter 3 byte yang melewati batas 255 byte
+	long := strings.Repeat("a", maxUserAgentLength-1) + "€€"
+
+	got := truncateUserAgent(long)
+	if got != strings.Repeat("a", maxUserAgentLength-1) {
+		t.Errorf("truncateUserAgent cut inside a rune: %d bytes, valid=%v", len(got), utf8.ValidString(got))
+	}
+
+	if got := truncateUserAgent("Mozilla/5.0 \xff"); got != "Mozilla/5.0 �" {
+		t.Errorf("truncateUserAgent invalid UTF-8 = %q", got)
+	}
+
+	for _, userAgent := range []string{strings.Repeat("é", 200), strings.Repeat("😀", 100)} {
+		got := truncateUserAgent(userAgent)
+		if len(got) > maxUserAgentLength || !utf8.ValidString(got) {
+			t.Errorf("truncateUserAgent = %d bytes, valid=%v", len(got), utf8.ValidString(got))
+		}
+	}
+}
+
+func TestSessionCheck(t *testing.T) {
+	database := openTestDB(t)
+	service := NewSessionService(storages.NewSessionRepository(database), security.NewJWTAdapter(security.NewHMACKeySet("secret"), time.Hour), time.Hour)
+
+	user := &domain.User{ID: 1, Username: "alice"}
+	if _, err := service.Start(user, domain.ClientInfo{UserAgent: "test", IP: "10.0.0.1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := service.List(user.ID)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("sessions = %v, %v", sessions, err)
+	}
+
+	active := &domain.JWTClaims{UserID: user.ID, RegisteredClaims: jwt.RegisteredClaims{ID: sessions[0].ID}}
+	if err := service.Check(active); err != nil {
+		t.Errorf("active session = %v", err)
+	}
+
+	if err := service.Check(&domain.JWTClaims{UserID: user.ID}); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("token without jti = %v, want ErrSessionRevoked", err)
+	}
+
+	if err := service.Check(&domain.JWTClaims{UserID: 2, RegisteredClaims: active.RegisteredClaims}); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("session of another user = %v, want ErrSessionRevoked", err)
+	}
+
+	if err := service.RevokeAll(user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.Check(active); !errors.Is(err, ErrSessionRevoked) {
+		t.Errorf("revoked session = %v, want ErrSessionRevoked", err)
+	}
+}
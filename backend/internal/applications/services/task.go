@@ -2,6 +2,7 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"task-management/internal/applications/ports/repository"
 	"task-management/internal/applications/ports/services"
 	"task-management/internal/domain"
@@ -10,13 +11,19 @@ import (
 	"gorm.io/gorm"
 )
 
+const importBatchSize = 500
+
 type taskService struct {
-	taskRepo repository.TaskRepository
+	taskRepo     repository.TaskRepository
+	uow          repository.UnitOfWork
+	notification services.NotificationService
 }
 
-func NewTaskService(repo repository.TaskRepository) services.TaskService {
+func NewTaskService(repo repository.TaskRepository, uow repository.UnitOfWork, notification services.NotificationService) services.TaskService {
 	return &taskService{
-		taskRepo: repo,
+		taskRepo:     repo,
+		uow:          uow,
+		notification: notification,
 	}
 }
 
@@ -29,9 +36,48 @@ func (t *taskService) CreateTask(userId uint, req *domain.Task) error {
 		req.Status = domain.ToDo
 	}
 
+	if req.Status == domain.Done {
+		now := time.Now()
+		req.CompletedAt = &now
+	}
+
 	return t.taskRepo.Create(req)
 }
 
+// ImportTasks implements services.TaskService.
+// Task di-insert per batch, masing-masing dalam transaksi sendiri. Jika satu batch gagal,
+// proses berhenti dan jumlah task yang sudah tersimpan dikembalikan bersama error-nya.
+func (t *taskService) ImportTasks(userId uint, tasks []domain.Task) (int, error) {
+	now := time.Now()
+
+	for i := range tasks {
+		tasks[i].UserID = userId
+		tasks[i].CreatedBy = userId
+
+		if tasks[i].Status == "" {
+			tasks[i].Status = domain.ToDo
+		}
+
+		if tasks[i].Status == domain.Done && tasks[i].CompletedAt == nil {
+			tasks[i].CompletedAt = &now
+		}
+	}
+
+	imported := 0
+
+	for start := 0; start < len(tasks); start += importBatchSize {
+		end := min(start+importBatchSize, len(tasks))
+
+		if err := t.taskRepo.CreateBatch(tasks[start:end]); err != nil {
+			return imported, err
+		}
+
+		imported = end
+	}
+
+	return imported, nil
+}
+
 // DeleteTask implements services.TaskService.
 func (t *taskService) DeleteTask(taskId uint, userId uint) error {
 	task, err := t.taskRepo.GetByID(taskId)
@@ -52,31 +98,108 @@ func (t *taskService) DeleteTask(taskId uint, userId uint) error {
 }
 
 // GetTasks implements services.TaskService.
-func (t *taskService) GetTasks(userId uint, status *domain.TaskStatus, deadline *time.Time) ([]domain.Task, error) {
-	return t.taskRepo.GetByUser(userId, status, deadline)
+func (t *taskService) GetTasks(userId uint, status *domain.TaskStatus, dueBefore *time.Time) ([]domain.Task, error) {
+	return t.taskRepo.GetByUser(userId, status, dueBefore)
+}
+
+// ExportTasks implements services.TaskService.
+func (t *taskService) ExportTasks(userId uint, status *domain.TaskStatus, dueBefore *time.Time, fn func(task *domain.Task) error) error {
+	return t.taskRepo.StreamByUser(userId, status, dueBefore, fn)
 }
 
 // UpdateTask implements services.TaskService.
+// Perubahan task dan notifikasi perubahan status disimpan dalam satu transaksi,
+// email notifikasi dikirim setelah transaksi berhasil.
 func (t *taskService) UpdateTask(arg *domain.Task, userId uint) error {
-	taskInDb, err := t.taskRepo.GetByID(arg.ID)
+	var notifications []domain.Notification
 
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errors.New("task not found")
+	err := t.uow.Do(func(repos repository.Repositories) error {
+		notifications = nil
+
+		taskInDb, err := repos.Tasks.GetByID(arg.ID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("task not found")
+			}
+			return err
 		}
+
+		if taskInDb.UserID != userId {
+			return errors.New("unauthorized")
+		}
+
+		previousStatus := taskInDb.Status
+
+		taskInDb.Title = arg.Title
+		taskInDb.Description = arg.Description
+		taskInDb.Status = arg.Status
+		taskInDb.Deadline = arg.Deadline
+
+		if previousStatus != taskInDb.Status {
+			taskInDb.CompletedAt = nil
+
+			if taskInDb.Status == domain.Done {
+				now := time.Now()
+				taskInDb.CompletedAt = &now
+			}
+		}
+
+		if err := repos.Tasks.Update(taskInDb); err != nil {
+			return err
+		}
+
+		if previousStatus == taskInDb.Status {
+			return nil
+		}
+
+		notifications = statusNotifications(taskInDb, previousStatus, userId)
+		for i := range notifications {
+			if err := repos.Notifications.Create(&notifications[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
 		return err
 	}
 
-	if taskInDb.UserID != userId {
-		return errors.New("unauthorized")
+	for i := range notifications {
+		t.notification.SendEmail(&notifications[i])
 	}
 
-	taskInDb.Title = arg.Title
-	taskInDb.Description = arg.Description
-	taskInDb.Status = arg.Status
-	taskInDb.Deadline = arg.Deadline
+	return nil
+}
+
+// statusNotifications membuat notifikasi untuk owner dan pembuat task,
+// kecuali user yang melakukan perubahan itu sendiri.
+func statusNotifications(task *domain.Task, previous domain.TaskStatus, actorId uint) []domain.Notification {
+	message := fmt.Sprintf("Task %q moved from %s to %s", task.Title, previous, task.Status)
+
+	var notifications []domain.Notification
+
+	for _, recipient := range []uint{task.UserID, task.CreatedBy} {
+		if recipient == 0 || recipient == actorId {
+			continue
+		}
+
+		taskID := task.ID
+		notifications = append(notifications, domain.Notification{
+			UserID:  recipient,
+			TaskID:  &taskID,
+			Type:    domain.NotificationTaskStatusChanged,
+			Message: message,
+		})
+
+		if task.UserID == task.CreatedBy {
+			break
+		}
+	}
 
-	return t.taskRepo.Update(taskInDb)
+	return notifications
 }
 
 // GetTaskById implements services.TaskService.
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"testing"
+)
+
+func TestUpdateTaskNotifiesCreatorOfAssignedTask(t *testing.T) {
+	database := openTestDB(t)
+
+	tasks := storages.NewTaskRepository(database)
+	notifications := storages.NewNotificationRepository(database)
+	users := storages.NewUserRepository(database)
+	notificationService := NewNotificationService(notifications, storages.NewNotificationPreferenceRepository(database), tasks, users, nil)
+	service := NewTaskService(tasks, storages.NewUnitOfWork(database), notificationService)
+
+	for _, username := range []string{"admin", "bob"} {
+		if err := users.Create(&domain.User{Name: username, Username: username, Password: "hash"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// task dari import admin (1) yang di-assign ke bob (2)
+	assigned := &domain.Task{UserID: 2, CreatedBy: 1, Title: "Assigned", Status: domain.ToDo}
+	own := &domain.Task{UserID: 2, CreatedBy: 2, Title: "Own", Status: domain.ToDo}
+	for _, task := range []*domain.Task{assigned, own} {
+		if err := tasks.Create(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, task := range []*domain.Task{assigned, own} {
+		update := *task
+		update.Status = domain.Done
+
+		if err := service.UpdateTask(&update, 2); err != nil {
+			t.Fatalf("UpdateTask %q: %v", task.Title, err)
+		}
+	}
+
+	inbox, total, err := notifications.GetByUser(1, false, 10, 0)
+	if err != nil || total != 1 {
+		t.Fatalf("creator notifications = %d, %v, want 1", total, err)
+	}
+
+	if inbox[0].Type != domain.NotificationTaskStatusChanged || *inbox[0].TaskID != assigned.ID {
+		t.Errorf("notification = %+v, want status change of the assigned task", inbox[0])
+	}
+
+	if _, total, _ := notifications.GetByUser(2, false, 10, 0); total != 0 {
+		t.Errorf("actor got %d notifications for own changes, want 0", total)
+	}
+
+	stored, _ := tasks.GetByID(assigned.ID)
+	if stored.Status != domain.Done || stored.CompletedAt == nil {
+		t.Errorf("stored task = %+v, want done with completed_at", stored)
+	}
+}
+
+func TestUpdateTaskRejectsOtherUsers(t *testing.T) {
+	database := openTestDB(t)
+
+	tasks := storages.NewTaskRepository(database)
+	service := NewTaskService(tasks, storages.NewUnitOfWork(database), nil)
+
+	task := &domain.Task{UserID: 1, CreatedBy: 1, Title: "Private", Status: domain.ToDo}
+	if err := tasks.Create(task); err != nil {
+		t.Fatal(err)
+	}
+
+	update := *task
+	update.Title = "Changed"
+
+	if err := service.UpdateTask(&update, 2); err == nil || err.Error() != "unauthorized" {
+		t.Fatalf("UpdateTask by other user = %v, want unauthorized", err)
+	}
+
+	if err := service.UpdateTask(&domain.Task{ID: 99}, 1); err == nil || err.Error() != "task not found" {
+		t.Fatalf("UpdateTask missing task = %v, want task not found", err)
+	}
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled  = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled = errors.New("two-factor enrollment not started")
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+)
+
+type twoFactorService struct {
+	userRepo     repository.UserRepository
+	recoveryRepo repository.RecoveryCodeRepository
+	totp         services.TOTPService
+	cipher       services.SecretCipher
+}
+
+// NewTwoFactorService membuat service 2FA. Secret TOTP disimpan terenkripsi dengan cipher.
+func NewTwoFactorService(
+	userRepo repository.UserRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	totp services.TOTPService,
+	cipher services.SecretCipher,
+) services.TwoFactorService {
+	return &twoFactorService{
+		userRepo:     userRepo,
+		recoveryRepo: recoveryRepo,
+		totp:         totp,
+		cipher:       cipher,
+	}
+}
+
+// Enroll implements services.TwoFactorService.
+func (s *twoFactorService) Enroll(userID uint) (*domain.TwoFactorEnrollment, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := s.cipher.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	// enroll ulang mengganti secret lama yang belum diverifikasi
+	user.TOTPSecret = sealed
+	user.TOTPLastStep = 0
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    s.totp.URI(secret, user.Username),
+	}, nil
+}
+
+// Enable implements services.TwoFactorService.
+func (s *twoFactorService) Enable(userID uint, code string) ([]string, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, ErrTwoFactorEnabled
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	secret, err := s.cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	// saat aktivasi hanya kode TOTP yang diterima, recovery code belum ada
+	step, ok := s.totp.Validate(secret, normalizeCode(code), time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	// secret dari enroll sebelum enkripsi diaktifkan ikut dienkripsi
+	if user.TOTPSecret, err = s.cipher.Encrypt(secret); err != nil {
+		return nil, err
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return s.issueRecoveryCodes(user.ID)
+}
+
+// Disable implements services.TwoFactorService.
+func (s *twoFactorService) Disable(userID uint, password, code string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+
+	// akun SSO tidak punya password, kode 2FA saja sudah cukup
+	if user.Password != "" && utils.CheckPassword(user.Password, password) != nil {
+		return ErrInvalidPassword
+	}
+
+	ok, err := s.VerifyCode(user, code)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	return s.recoveryRepo.DeleteByUser(user.ID)
+}
+
+// RegenerateRecoveryCodes implements services.TwoFactorService.
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	ok, err := s.VerifyCode(user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	return s.issueRecoveryCodes(user.ID)
+}
+
+// VerifyCode implements services.TwoFactorService.
+func (s *twoFactorService) VerifyCode(user *domain.User, code string) (bool, error) {
+	if !user.TOTPEnabled {
+		return false, ErrTwoFactorNotEnabled
+	}
+
+	code = normalizeCode(code)
+	now := time.Now()
+
+	secret, err := s.cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return false, err
+	}
+
+	if step, ok := s.totp.Validate(secret, code, now); ok {
+		// kode dari time step yang sudah pernah dipakai ditolak (replay), dicek di database
+		// supaya dua request bersamaan dengan kode yang sama tidak sama-sama diterima
+		updated, err := s.userRepo.UpdateTOTPStep(user.ID, step)
+		if err != nil || !updated {
+			return false, err
+		}
+
+		user.TOTPLastStep = step
+
+		// secret lama yang tersimpan tanpa enkripsi dienkripsi saat pertama dipakai
+		if user.TOTPSecret == secret {
+			if user.TOTPSecret, err = s.cipher.Encrypt(secret); err != nil {
+				return false, err
+			}
+
+			return true, s.userRepo.Update(user)
+		}
+
+		return true, nil
+	}
+
+	used, err := s.recoveryRepo.Use(user.ID, utils.HashToken(code), now)
+	if err != nil {
+		return false, err
+	}
+
+	if used {
+		logger.Info("recovery code used", zap.Uint("user_id", user.ID))
+	}
+
+	return used, nil
+}
+
+func (s *twoFactorService) findUser(userID uint) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+
+	return user, nil
+}
+
+// issueRecoveryCodes membuat recovery code baru dan mengganti yang lama. Kode plain hanya
+// dikembalikan sekali, yang disimpan hanya hash-nya.
+func (s *twoFactorService) issueRecoveryCodes(userID uint) ([]string, error) {
+	plain := make([]string, 0, domain.RecoveryCodeCount)
+	codes := make([]domain.RecoveryCode, 0, domain.RecoveryCodeCount)
+
+	for i := 0; i < domain.RecoveryCodeCount; i++ {
+		raw, err := utils.GenerateToken(5)
+		if err != nil {
+			return nil, err
+		}
+
+		code := raw[:5] + "-" + raw[5:]
+		plain = append(plain, code)
+		codes = append(codes, domain.RecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashToken(code),
+		})
+	}
+
+	if err := s.recoveryRepo.Replace(userID, codes); err != nil {
+		return nil, err
+	}
+
+	return plain, nil
+}
+
+// normalizeCode membuang spasi dan menyamakan huruf supaya kode yang diketik manual tetap cocok.
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), " ", ""))
+}
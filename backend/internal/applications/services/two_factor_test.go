@@ -0,0 +1,197 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/security"
+	"task-management/internal/utils"
+	"testing"
+	"time"
+)
+
+// stepTOTP menerima kode "step-N" sebagai kode untuk time step N, apa pun secret-nya.
+type stepTOTP struct {
+	secret string
+}
+
+func (f *stepTOTP) GenerateSecret() (string, error) {
+	return f.secret, nil
+}
+
+func (f *stepTOTP) URI(secret, account string) string {
+	return "otpauth://totp/" + account + "?secret=" + secret
+}
+
+func (f *stepTOTP) Validate(secret, code string, at time.Time) (int64, bool) {
+	digits, ok := strings.CutPrefix(code, "step-")
+	if secret != f.secret || !ok {
+		return 0, false
+	}
+
+	step, err := strconv.ParseInt(digits, 10, 64)
+	return step, err == nil
+}
+
+func newTestTwoFactor(t *testing.T) (*twoFactorService, repository.UserRepository) {
+	t.Helper()
+
+	database := openTestDB(t)
+	users := storages.NewUserRepository(database)
+
+	cipher, err := security.NewSecretCipher("test key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewTwoFactorService(users, storages.NewRecoveryCodeRepository(database), &stepTOTP{secret: "PLAINSECRET"}, cipher)
+	return service.(*twoFactorService), users
+}
+
+func createTwoFactorUser(t *testing.T, users repository.UserRepository, password string) *domain.User {
+	t.Helper()
+
+	user := &domain.User{Name: "Alice", Username: "alice", Password: password}
+	if err := users.Create(user); err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
+func TestTwoFactorSecretIsEncryptedAtRest(t *testing.T) {
+	service, users := newTestTwoFactor(t)
+	user := createTwoFactorUser(t, users, "hash")
+
+	enrollment, err := service.Enroll(user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, _ := users.FindByID(user.ID)
+	if enrollment.Secret != "PLAINSECRET" || stored.TOTPSecret == enrollment.Secret {
+		t.Fatalf("stored secret = %q, want it encrypted", stored.TOTPSecret)
+	}
+
+	codes, err := service.Enable(user.ID, "step-1")
+	if err != nil || len(codes) != domain.RecoveryCodeCount {
+		t.Fatalf("Enable = %d codes, %v", len(codes), err)
+	}
+}
+
+func TestTwoFactorCodeIsAcceptedOnce(t *testing.T) {
+	service, users := newTestTwoFactor(t)
+	user := createTwoFactorUser(t, users, "hash")
+
+	if _, err := service.Enroll(user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.Enable(user.ID, "step-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// setiap request membaca user sendiri, seperti login dari dua tab
+			current, err := users.FindByID(user.ID)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			ok, err := service.VerifyCode(current, "step-2")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			if ok {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if accepted != 1 {
+		t.Fatalf("same code accepted %d times, want once", accepted)
+	}
+
+	current, _ := users.FindByID(user.ID)
+	if ok, _ := service.VerifyCode(current, "step-1"); ok {
+		t.Error("code from an older step was accepted")
+	}
+}
+
+func TestTwoFactorEncryptsLegacySecretOnUse(t *testing.T) {
+	service, users := newTestTwoFactor(t)
+	user := createTwoFactorUser(t, users, "hash")
+
+	user.TOTPSecret = "PLAINSECRET"
+	user.TOTPEnabled = true
+	if err := users.Update(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := service.VerifyCode(user, "step-3"); err != nil || !ok {
+		t.Fatalf("VerifyCode with legacy secret = %v, %v", ok, err)
+	}
+
+	stored, _ := users.FindByID(user.ID)
+	if stored.TOTPSecret == "PLAINSECRET" || stored.TOTPLastStep != 3 {
+		t.Fatalf("stored user = secret %q, step %d, want encrypted secret and step 3", stored.TOTPSecret, stored.TOTPLastStep)
+	}
+}
+
+func TestTwoFactorDisable(t *testing.T) {
+	hashed, err := utils.HashPassword("correct password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tt := range map[string]struct {
+		stored   string
+		password string
+		want     error
+	}{
+		"password account with wrong password": {hashed, "wrong", ErrInvalidPassword},
+		"password account":                     {hashed, "correct password", nil},
+		"sso account without password":         {"", "", nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service, users := newTestTwoFactor(t)
+			user := createTwoFactorUser(t, users, tt.stored)
+
+			if _, err := service.Enroll(user.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := service.Enable(user.ID, "step-1"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := service.Disable(user.ID, tt.password, "step-2"); !errors.Is(err, tt.want) {
+				t.Fatalf("Disable = %v, want %v", err, tt.want)
+			}
+
+			stored, _ := users.FindByID(user.ID)
+			if stored.TOTPEnabled != (tt.want != nil) {
+				t.Errorf("TOTPEnabled = %v after Disable", stored.TOTPEnabled)
+			}
+		})
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"github.com/spf13/viper"
 )
 
+// DatabaseConfig, Driver mysql (default), postgres atau sqlite. Untuk sqlite, Name adalah
+// path file database atau ":memory:", field koneksi lainnya diabaikan.
+// AutoMigrate menjalankan migrasi saat server start, default true.
 type DatabaseConfig struct {
 	Driver      string
+	SSLMode     string `mapstructure:"ssl_mode"`
+	AutoMigrate *bool  `mapstructure:"auto_migrate"`
 	Host        string
 	Port        string
 	User        string
@@ -16,14 +21,163 @@ type DatabaseConfig struct {
 	MaxLifeTime int
 }
 
+func (d DatabaseConfig) AutoMigrateEnabled() bool {
+	return d.AutoMigrate == nil || *d.AutoMigrate
+}
+
+// RepositoryConfig, Store "db" (default) atau "memory". Store memory menyimpan user dan task
+// di memory proses untuk demo dan integration test, data lain tetap di database sehingga hanya
+// boleh dipakai dengan database SQLite ":memory:".
+type RepositoryConfig struct {
+	Store string
+}
+
+// ServerConfig, Mode "production" membuat server menolak start selama akun default masih ada.
+// TrustedProxies adalah IP/CIDR reverse proxy yang boleh mengisi X-Forwarded-For, kosong berarti
+// IP client selalu diambil dari koneksi.
 type ServerConfig struct {
-	Port int
+	Port           int
+	Mode           string
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+func (s ServerConfig) IsProduction() bool {
+	return s.Mode == "production"
+}
+
+// ProfileConfig mengatur verifikasi email, VerifyTTL dalam menit, VerifyURL halaman frontend
+// untuk konfirmasi email (opsional). DeletionPolicy "delete" (default) atau "anonymize".
+type ProfileConfig struct {
+	VerifyTTL      int    `mapstructure:"verify_ttl"`
+	VerifyURL      string `mapstructure:"verify_url"`
+	DeletionPolicy string `mapstructure:"deletion_policy"`
+}
+
+// StorageConfig, Dir adalah direktori penyimpanan file upload seperti avatar.
+type StorageConfig struct {
+	Dir string
+}
+
+// BootstrapConfig, Token membuka endpoint setup admin pertama. Kosongkan setelah admin dibuat.
+type BootstrapConfig struct {
+	Token string
+}
+
+// NotificationConfig mengatur reminder deadline dan digest. Window dalam jam,
+// interval dalam menit, digest hour adalah jam pengiriman digest (0-23) di zona waktu tiap user.
+type NotificationConfig struct {
+	DeadlineWindow   int `mapstructure:"deadline_window"`
+	ReminderInterval int `mapstructure:"reminder_interval"`
+	DigestHour       int `mapstructure:"digest_hour"`
+}
+
+// SMTPConfig untuk pengiriman email. Jika Host kosong, email hanya dicatat di log.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// LoginProtectionConfig mengatur proteksi brute-force login. Store bisa "memory" atau "db",
+// window dan lockout dalam menit, delay dalam detik.
+type LoginProtectionConfig struct {
+	Store         string `mapstructure:"store"`
+	MaxAttempts   int    `mapstructure:"max_attempts"`
+	IPMaxAttempts int    `mapstructure:"ip_max_attempts"`
+	FreeAttempts  int    `mapstructure:"free_attempts"`
+	Window        int    `mapstructure:"window"`
+	Lockout       int    `mapstructure:"lockout"`
+	BaseDelay     int    `mapstructure:"base_delay"`
+	MaxDelay      int    `mapstructure:"max_delay"`
+}
+
+// PasswordConfig mengatur password policy dan reset password. DenylistFile berisi password
+// yang pernah bocor, ResetTTL dalam menit, ResetURL halaman frontend untuk reset (opsional).
+type PasswordConfig struct {
+	MinLength     int    `mapstructure:"min_length"`
+	RequireUpper  bool   `mapstructure:"require_upper"`
+	RequireLower  bool   `mapstructure:"require_lower"`
+	RequireDigit  bool   `mapstructure:"require_digit"`
+	RequireSymbol bool   `mapstructure:"require_symbol"`
+	DenylistFile  string `mapstructure:"denylist_file"`
+	ResetTTL      int    `mapstructure:"reset_ttl"`
+	ResetURL      string `mapstructure:"reset_url"`
+}
+
+// OIDCConfig untuk login SSO. Login OIDC aktif jika Issuer diisi. SuccessRedirect opsional,
+// jika diisi user diarahkan ke sana dengan access token di fragment (#token=...).
+type OIDCConfig struct {
+	Issuer          string
+	ClientID        string `mapstructure:"client_id"`
+	ClientSecret    string `mapstructure:"client_secret"`
+	RedirectURL     string `mapstructure:"redirect_url"`
+	Scopes          []string
+	SuccessRedirect string `mapstructure:"success_redirect"`
+}
+
+// JWTKeyConfig adalah satu key JWT. Key yang dipakai menandatangani butuh PrivateKeyFile,
+// key lama yang hanya untuk verifikasi cukup PublicKeyFile (PEM).
+type JWTKeyConfig struct {
+	ID             string
+	PrivateKeyFile string `mapstructure:"private_key_file"`
+	PublicKeyFile  string `mapstructure:"public_key_file"`
+}
+
+// JWTConfig mengatur key JWT asimetris (RS256/ES256/EdDSA). Jika Keys kosong, token
+// ditandatangani HS256 dengan Secret. AcceptHS256 tetap menerima token HS256 lama saat migrasi.
+type JWTConfig struct {
+	SigningKey  string `mapstructure:"signing_key"`
+	Keys        []JWTKeyConfig
+	AcceptHS256 bool `mapstructure:"accept_hs256"`
+}
+
+// TwoFactorConfig mengatur penyimpanan secret TOTP. EncryptionKey kosong berarti key diturunkan
+// dari Secret, mengganti key membuat semua user dengan 2FA harus mendaftar ulang.
+type TwoFactorConfig struct {
+	EncryptionKey string `mapstructure:"encryption_key"`
+}
+
+// RedisConfig dipakai oleh store yang bisa dibagi antar instance, misalnya rate limit.
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// RateLimitRule adalah jumlah request (limit) per period dalam detik.
+type RateLimitRule struct {
+	Limit  int
+	Period int
+}
+
+// RateLimitConfig mengatur rate limit token bucket. Store bisa "memory" atau "redis",
+// Auth berlaku untuk route /auth dan API untuk route lainnya.
+type RateLimitConfig struct {
+	Disabled bool
+	Store    string
+	API      RateLimitRule
+	Auth     RateLimitRule
 }
 
 type AppConfig struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Secret   string
+	Database     DatabaseConfig
+	Repository   RepositoryConfig
+	Server       ServerConfig
+	Notification NotificationConfig
+	SMTP         SMTPConfig
+	Login        LoginProtectionConfig
+	Password     PasswordConfig
+	OIDC         OIDCConfig
+	JWT          JWTConfig
+	TwoFactor    TwoFactorConfig `mapstructure:"two_factor"`
+	RateLimit    RateLimitConfig `mapstructure:"rate_limit"`
+	Redis        RedisConfig
+	Bootstrap    BootstrapConfig
+	Profile      ProfileConfig
+	Storage      StorageConfig
+	Secret       string
 }
 
 var Config AppConfig
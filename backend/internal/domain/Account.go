@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeletedUsernamePrefix adalah awalan username akun yang sudah dianonimkan.
+const DeletedUsernamePrefix = "deleted-"
+
+// IsReservedUsername true untuk username yang bisa tertukar dengan akun yang sudah dianonimkan,
+// username seperti ini tidak boleh dipakai user baru.
+func IsReservedUsername(username string) bool {
+	return strings.HasPrefix(strings.ToLower(username), DeletedUsernamePrefix)
+}
+
+// DeletionPolicy menentukan apa yang terjadi dengan data user saat akunnya dihapus.
+type DeletionPolicy string
+
+const (
+	// DeletionDelete menghapus user beserta semua task dan data miliknya.
+	DeletionDelete DeletionPolicy = "delete"
+	// DeletionAnonymize menyimpan baris user dan task-nya tanpa data pribadi, mis. untuk statistik.
+	// Data lain milik user (notifikasi, session, token, identitas SSO) tetap dihapus.
+	DeletionAnonymize DeletionPolicy = "anonymize"
+)
+
+func (p DeletionPolicy) IsValid() bool {
+	return p == DeletionDelete || p == DeletionAnonymize
+}
+
+// Anonymize menghapus semua data pribadi dari user. Username diganti dengan nilai unik
+// supaya username lama bisa dipakai lagi, dan akun dinonaktifkan.
+func (u *User) Anonymize(now time.Time) {
+	u.Name = "Deleted user"
+	u.Username = fmt.Sprintf("%s%d", DeletedUsernamePrefix, u.ID)
+	u.Email = ""
+	u.EmailVerifiedAt = nil
+	u.Password = ""
+	u.Timezone = ""
+	u.Locale = ""
+	u.AvatarUpdatedAt = nil
+	u.Role = RoleUser
+	u.DeactivatedAt = &now
+	u.TOTPSecret = ""
+	u.TOTPEnabled = false
+	u.TOTPLastStep = 0
+}
+
+// PersonalData adalah semua data yang disimpan tentang satu user, untuk export data pribadi.
+type PersonalData struct {
+	ExportedAt             time.Time
+	User                   User
+	Tasks                  []Task
+	Notifications          []Notification
+	NotificationPreference *NotificationPreference
+	Sessions               []Session
+	APITokens              []APIToken
+	Identities             []UserIdentity
+	// LoginAudits adalah login gagal dengan username user, termasuk IP asalnya
+	LoginAudits []LoginAudit
+	// Avatar nil jika user tidak punya avatar
+	Avatar *Avatar
+}
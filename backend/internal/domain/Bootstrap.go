@@ -0,0 +1,21 @@
+package domain
+
+// Credential adalah pasangan username dan password.
+type Credential struct {
+	Username string
+	Password string
+}
+
+// DefaultCredentials adalah akun bawaan yang dulu dibuat otomatis di database baru.
+// Server menolak start di mode production selama salah satu akun ini masih ada.
+var DefaultCredentials = []Credential{
+	{Username: "admin", Password: "admin123"},
+}
+
+// AdminSetup adalah data admin pertama yang dibuat lewat endpoint setup.
+type AdminSetup struct {
+	Name     string
+	Username string
+	Email    string
+	Password string
+}
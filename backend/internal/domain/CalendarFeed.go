@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// CalendarFeed menyimpan token feed ICS milik user. Yang disimpan hanya hash dari token.
+type CalendarFeed struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"uniqueIndex;not null" json:"user_id"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
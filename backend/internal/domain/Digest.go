@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// Digest adalah ringkasan task milik satu user untuk satu periode.
+type Digest struct {
+	Frequency DigestFrequency
+	From      time.Time
+	To        time.Time
+	Overdue   []Task
+	DueSoon   []Task
+	Completed []Task
+}
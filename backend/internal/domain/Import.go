@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// ExternalTask adalah task dari tool lain (Trello, Jira, GitHub) sebelum dipetakan ke Task.
+type ExternalTask struct {
+	ExternalID  string
+	Title       string
+	Description string
+	Status      string
+	Labels      []string
+	Assignees   []string
+	Deadline    *time.Time
+}
+
+// ImportMapping memetakan nilai eksternal ke nilai lokal. Key dibandingkan case-insensitive.
+type ImportMapping struct {
+	Statuses  map[string]TaskStatus `json:"statuses"`
+	Labels    map[string]string     `json:"labels"`
+	Assignees map[string]string     `json:"assignees"`
+}
+
+// ExternalTaskLink mencatat task hasil import agar import ulang tidak membuat duplikat.
+type ExternalTaskLink struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	ImportedBy uint      `gorm:"uniqueIndex:idx_external_task;not null" json:"imported_by"`
+	Source     string    `gorm:"size:20;uniqueIndex:idx_external_task;not null" json:"source"`
+	ExternalID string    `gorm:"size:191;uniqueIndex:idx_external_task;not null" json:"external_id"`
+	TaskID     uint      `gorm:"index;not null" json:"task_id"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+type ImportFailure struct {
+	Index      int
+	ExternalID string
+	Reason     string
+}
+
+type ImportReport struct {
+	Total    int
+	Created  int
+	Updated  int
+	Failures []ImportFailure
+}
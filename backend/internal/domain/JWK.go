@@ -0,0 +1,19 @@
+package domain
+
+// JWK adalah satu public key dalam format JSON Web Key (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet adalah isi endpoint jwks_uri.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
@@ -3,7 +3,19 @@ package domain
 import "github.com/golang-jwt/jwt/v5"
 
 type JWTClaims struct {
-	UserID   uint   `json:"user_id"`
-	Username string `json:"username"`
+	UserID   uint     `json:"user_id"`
+	Username string   `json:"username"`
+	Scopes   []string `json:"scopes,omitempty"`
 	jwt.RegisteredClaims
 }
+
+// HasScope mengecek apakah token boleh mengakses scope tertentu.
+// Scope tasks:write juga memberi akses tasks:read.
+func (c *JWTClaims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope || (scope == ScopeTasksRead && s == ScopeTasksWrite) {
+			return true
+		}
+	}
+	return false
+}
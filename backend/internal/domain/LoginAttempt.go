@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginAttempt menghitung login gagal untuk satu key (username atau IP).
+type LoginAttempt struct {
+	Key          string     `gorm:"primaryKey;column:attempt_key;size:191" json:"key"`
+	Failures     int        `gorm:"not null" json:"failures"`
+	FirstFailure time.Time  `json:"first_failure"`
+	LastFailure  time.Time  `gorm:"index" json:"last_failure"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty"`
+}
+
+// LoginAudit mencatat setiap login yang gagal atau ditolak.
+type LoginAudit struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Username  string    `gorm:"size:100;index" json:"username"`
+	IP        string    `gorm:"size:45;index" json:"ip"`
+	Reason    string    `gorm:"size:50;not null" json:"reason"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+const (
+	LoginFailUnknownUser     = "unknown_user"
+	LoginFailInvalidPassword = "invalid_password"
+	LoginFailThrottled       = "throttled"
+	LoginFailInvalidCode     = "invalid_2fa_code"
+)
+
+// LoginPolicy mengatur batas login gagal sebelum request diperlambat atau dikunci.
+type LoginPolicy struct {
+	MaxAttempts   int
+	IPMaxAttempts int
+	FreeAttempts  int
+	Window        time.Duration
+	Lockout       time.Duration
+	BaseDelay     time.Duration
+	MaxDelay      time.Duration
+}
+
+// LoginThrottledError dikembalikan saat login ditolak karena terlalu banyak percobaan gagal.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter.Round(time.Second))
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type NotificationType string
+
+const (
+	// NotificationTaskAssigned dikirim ke assignee saat import memberikan task ke user lain.
+	NotificationTaskAssigned NotificationType = "task_assigned"
+	// NotificationTaskStatusChanged dikirim ke owner atau pembuat task yang bukan pengubah status,
+	// jadi hanya untuk task yang dibuat oleh user lain (task import yang di-assign).
+	NotificationTaskStatusChanged NotificationType = "task_status_changed"
+	// NotificationDeadlineApproach dikirim sekali per task oleh job reminder deadline.
+	NotificationDeadlineApproach NotificationType = "deadline_approaching"
+)
+
+type Notification struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	UserID    uint             `gorm:"index;not null" json:"user_id"`
+	TaskID    *uint            `gorm:"index" json:"task_id,omitempty"`
+	Type      NotificationType `gorm:"size:50;not null" json:"type"`
+	Message   string           `gorm:"type:text;not null" json:"message"`
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	CreatedAt time.Time        `gorm:"autoCreateTime" json:"created_at"`
+}
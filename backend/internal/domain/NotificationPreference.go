@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+type DigestFrequency string
+
+const (
+	DigestNone   DigestFrequency = "none"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+type NotificationPreference struct {
+	ID               uint            `gorm:"primaryKey" json:"id"`
+	UserID           uint            `gorm:"uniqueIndex;not null" json:"user_id"`
+	EmailEnabled     bool            `gorm:"not null" json:"email_enabled"`
+	TaskAssigned     bool            `gorm:"not null" json:"task_assigned"`
+	DeadlineReminder bool            `gorm:"not null" json:"deadline_reminder"`
+	Digest           DigestFrequency `gorm:"size:10;not null" json:"digest"`
+	UpdatedAt        time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// DefaultNotificationPreference dipakai untuk user yang belum pernah menyimpan preferensi.
+func DefaultNotificationPreference(userID uint) *NotificationPreference {
+	return &NotificationPreference{
+		UserID:           userID,
+		EmailEnabled:     true,
+		TaskAssigned:     true,
+		DeadlineReminder: true,
+		Digest:           DigestNone,
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// UserIdentity menghubungkan user dengan akun di OIDC provider berdasarkan issuer dan subject.
+type UserIdentity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Issuer    string    `gorm:"size:191;not null;uniqueIndex:idx_identity_subject" json:"issuer"`
+	Subject   string    `gorm:"size:191;not null;uniqueIndex:idx_identity_subject" json:"subject"`
+	Email     string    `gorm:"size:255" json:"email"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// OIDCLoginState menyimpan state, nonce, dan PKCE verifier antara redirect ke provider dan callback.
+type OIDCLoginState struct {
+	State        string    `gorm:"primaryKey;size:64" json:"state"`
+	Nonce        string    `gorm:"size:64;not null" json:"-"`
+	CodeVerifier string    `gorm:"size:128;not null" json:"-"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// OIDCIdentity adalah claim dari ID token yang sudah diverifikasi.
+type OIDCIdentity struct {
+	Issuer            string
+	Subject           string
+	Nonce             string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// OIDCAuthorization adalah URL redirect ke provider beserta state yang harus dicocokkan saat callback.
+type OIDCAuthorization struct {
+	URL   string
+	State string
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bcryptMaxBytes adalah batas panjang input bcrypt, sisa byte akan diabaikan diam-diam.
+const bcryptMaxBytes = 72
+
+// PasswordPolicy adalah aturan password baru saat register, ganti password, dan reset.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// Denylist berisi password yang pernah bocor (huruf kecil), dibaca dari file
+	Denylist map[string]struct{}
+}
+
+// PasswordPolicyError berisi semua aturan yang tidak dipenuhi.
+type PasswordPolicyError struct {
+	Problems []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password " + strings.Join(e.Problems, ", ")
+}
+
+// Validate mengembalikan PasswordPolicyError jika password tidak memenuhi policy.
+func (p PasswordPolicy) Validate(password string) error {
+	var problems []string
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+
+	if len(password) > bcryptMaxBytes {
+		problems = append(problems, fmt.Sprintf("must be at most %d bytes", bcryptMaxBytes))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	if p.RequireUpper && !upper {
+		problems = append(problems, "must contain an uppercase letter")
+	}
+
+	if p.RequireLower && !lower {
+		problems = append(problems, "must contain a lowercase letter")
+	}
+
+	if p.RequireDigit && !digit {
+		problems = append(problems, "must contain a digit")
+	}
+
+	if p.RequireSymbol && !symbol {
+		problems = append(problems, "must contain a symbol")
+	}
+
+	if _, found := p.Denylist[strings.ToLower(password)]; found {
+		problems = append(problems, "is too common or has appeared in a data breach")
+	}
+
+	if len(problems) > 0 {
+		return &PasswordPolicyError{Problems: problems}
+	}
+
+	return nil
+}
+
+// PasswordResetToken adalah token reset password sekali pakai, yang disimpan hanya hash-nya.
+type PasswordResetToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// PasswordReset adalah isi email reset password. URL kosong jika reset URL tidak dikonfigurasi.
+type PasswordReset struct {
+	Token     string
+	URL       string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// AvatarSizes adalah ukuran (piksel, persegi) yang dibuat dari setiap avatar yang diupload.
+var AvatarSizes = []int{32, 64, 128, 256}
+
+// MaxAvatarBytes adalah batas ukuran file avatar yang diupload.
+const MaxAvatarBytes = 5 << 20
+
+// DefaultAvatarSize dipakai jika ukuran tidak diminta.
+const DefaultAvatarSize = 128
+
+func IsAvatarSize(size int) bool {
+	for _, s := range AvatarSizes {
+		if s == size {
+			return true
+		}
+	}
+	return false
+}
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// IsValidLocale menerima tag bahasa sederhana seperti "id" atau "en-US".
+func IsValidLocale(locale string) bool {
+	return localePattern.MatchString(locale)
+}
+
+// ProfileUpdate adalah perubahan profil dari user, field nil tidak diubah.
+type ProfileUpdate struct {
+	Name     *string
+	Email    *string
+	Timezone *string
+	Locale   *string
+}
+
+// EmailVerificationToken adalah token sekali pakai untuk mengonfirmasi alamat email baru.
+// Email user baru diganti setelah token dipakai.
+type EmailVerificationToken struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	Email     string    `gorm:"size:255;not null" json:"email"`
+	TokenHash string    `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// EmailVerification adalah isi email verifikasi. URL kosong jika verify URL tidak dikonfigurasi.
+type EmailVerification struct {
+	Email     string
+	Token     string
+	URL       string
+	ExpiresAt time.Time
+}
+
+// Avatar adalah satu ukuran avatar yang siap dikirim ke client.
+type Avatar struct {
+	Data        []byte
+	ContentType string
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit adalah aturan token bucket: bucket berisi Limit token dan terisi penuh kembali dalam Period.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+}
+
+// RateLimitResult adalah hasil pengambilan satu token dari bucket.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// NewRateLimitResult menghitung sisa token, waktu sampai bucket penuh, dan waktu tunggu
+// sampai token berikutnya tersedia dari jumlah token setelah request diproses.
+func NewRateLimitResult(rule RateLimit, tokens float64, allowed bool) *RateLimitResult {
+	perToken := rule.Period / time.Duration(rule.Limit)
+
+	result := &RateLimitResult{
+		Allowed:   allowed,
+		Limit:     rule.Limit,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(rule.Limit) - tokens) * float64(perToken)),
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+
+	return result
+}
@@ -0,0 +1,43 @@
+package domain
+
+const (
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeProfile    = "profile"
+	ScopeAdmin      = "admin"
+)
+
+// Scopes adalah daftar scope yang dikenal, dipakai untuk validasi input.
+var Scopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeProfile, ScopeAdmin}
+
+// DefaultUserScopes diberikan ke token hasil login username/password.
+var DefaultUserScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeProfile}
+
+// UserScopes adalah scope token login untuk user, admin mendapat scope admin tambahan.
+func UserScopes(user *User) []string {
+	if user.IsAdmin() {
+		return append([]string{ScopeAdmin}, DefaultUserScopes...)
+	}
+
+	return DefaultUserScopes
+}
+
+// WithoutScope mengembalikan salinan scopes tanpa scope tertentu.
+func WithoutScope(scopes []string, scope string) []string {
+	filtered := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if s != scope {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+func IsValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
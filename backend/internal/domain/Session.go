@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Session adalah satu login yang sudah diterbitkan. ID session dibawa di claim jti access token.
+type Session struct {
+	ID         string     `gorm:"primaryKey;size:32" json:"id"`
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	UserAgent  string     `gorm:"size:255" json:"user_agent"`
+	IP         string     `gorm:"size:45" json:"ip"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IsActive mengecek apakah session belum dicabut dan belum kedaluwarsa.
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(now)
+}
+
+// ClientInfo adalah informasi perangkat yang melakukan login.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
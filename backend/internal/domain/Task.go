@@ -17,6 +17,8 @@ type Task struct {
 	Description string     `gorm:"type:text" json:"description"`
 	Status      TaskStatus `gorm:"size:20;not null;default:'To Do'" json:"status"`
 	Deadline    *time.Time `json:"deadline,omitempty"`
+	Labels      []string   `gorm:"type:text;serializer:json" json:"labels,omitempty"`
 	CreatedBy   uint       `json:"created_by"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
 	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
 }
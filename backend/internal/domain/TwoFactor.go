@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// RecoveryCodeCount adalah jumlah recovery code yang dibuat setiap kali 2FA diaktifkan.
+const RecoveryCodeCount = 10
+
+// RecoveryCode adalah kode cadangan sekali pakai untuk login saat authenticator tidak tersedia.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"size:64;not null;index" json:"-"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TwoFactorEnrollment berisi secret dan URI otpauth:// untuk dipindai aplikasi authenticator.
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// LoginResult adalah hasil login. Jika TwoFactorRequired, Token berisi challenge token
+// berumur pendek yang harus ditukar dengan kode 2FA, bukan access token.
+type LoginResult struct {
+	Token             string
+	User              *User
+	TwoFactorRequired bool
+}
@@ -2,10 +2,64 @@ package domain
 
 import "time"
 
+// Role adalah peran sistem user. Admin bisa mengelola semua user lewat route /admin.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
 type User struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
 	Name      string    `gorm:"size:100;not null" json:"name"`
 	Username  string    `gorm:"size:100;uniqueIndex;not null" json:"username"`
+	Email     string    `gorm:"size:255" json:"email"`
 	Password  string    `gorm:"size:255;not null" json:"-"`
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// nil jika email belum pernah dikonfirmasi lewat link verifikasi
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// Timezone nama zona IANA (mis. Asia/Jakarta), kosong berarti UTC
+	Timezone string `gorm:"size:64" json:"timezone"`
+	Locale   string `gorm:"size:16" json:"locale"`
+	// waktu avatar terakhir diganti, nil jika user belum punya avatar
+	AvatarUpdatedAt *time.Time `json:"avatar_updated_at,omitempty"`
+
+	Role Role `gorm:"size:20;not null;default:user" json:"role"`
+	// user yang dinonaktifkan tidak bisa login dan semua tokennya ditolak
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+
+	// token yang dibuat sebelum password diganti tidak berlaku lagi
+	PasswordChangedAt *time.Time `json:"-"`
+
+	// TOTP 2FA, secret disimpan terenkripsi saat enroll dan baru aktif setelah kode pertama diverifikasi
+	TOTPSecret   string `gorm:"size:255" json:"-"`
+	TOTPEnabled  bool   `gorm:"not null;default:false" json:"totp_enabled"`
+	TOTPLastStep int64  `json:"-"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// HasVerifiedEmail true jika email user sudah dikonfirmasi, hanya alamat ini yang boleh dikirimi email.
+func (u *User) HasVerifiedEmail() bool {
+	return u.Email != "" && u.EmailVerifiedAt != nil
+}
+
+// TaskCounts adalah jumlah task milik user per status.
+type TaskCounts struct {
+	Total    int64                `json:"total"`
+	ByStatus map[TaskStatus]int64 `json:"by_status"`
+}
+
+// UserOverview adalah data user beserta jumlah task untuk halaman admin.
+type UserOverview struct {
+	User  User
+	Tasks TaskCounts
 }
@@ -0,0 +1,48 @@
+package blob
+
+import (
+	"sync"
+	"task-management/internal/applications/ports/services"
+)
+
+// MemoryStorage menyimpan blob di memory proses, dipakai bersama repository memory
+// supaya file milik user hilang bersama user saat restart.
+type MemoryStorage struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryStorage() services.BlobStorage {
+	return &MemoryStorage{blobs: map[string][]byte{}}
+}
+
+// Put implements services.BlobStorage.
+func (m *MemoryStorage) Put(key string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blobs[key] = append([]byte{}, data...)
+	return nil
+}
+
+// Get implements services.BlobStorage.
+func (m *MemoryStorage) Get(key string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.blobs[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return append([]byte{}, data...), nil
+}
+
+// Delete implements services.BlobStorage.
+func (m *MemoryStorage) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.blobs, key)
+	return nil
+}
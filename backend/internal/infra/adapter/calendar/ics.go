@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"task-management/internal/domain"
+	"time"
+)
+
+type Component string
+
+const (
+	ComponentEvent Component = "VEVENT"
+	ComponentTodo  Component = "VTODO"
+)
+
+const (
+	icsTimeFormat = "20060102T150405Z"
+	maxLineLength = 75
+)
+
+// Encode menulis task sebagai iCalendar (RFC 5545). Pada mode VEVENT task yang sudah
+// Done tidak ditampilkan, pada mode VTODO task tersebut ditandai COMPLETED.
+func Encode(w io.Writer, tasks []domain.Task, component Component, now time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Task Management//Task Deadlines//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "Task Deadlines")
+
+	for _, task := range tasks {
+		if task.Deadline == nil {
+			continue
+		}
+
+		if component == ComponentEvent && task.Status == domain.Done {
+			continue
+		}
+
+		deadline := task.Deadline.UTC().Format(icsTimeFormat)
+
+		line("BEGIN", string(component))
+		line("UID", fmt.Sprintf("task-%d@task-management", task.ID))
+		line("DTSTAMP", now.UTC().Format(icsTimeFormat))
+		line("CREATED", task.CreatedAt.UTC().Format(icsTimeFormat))
+		line("SUMMARY", escapeText(task.Title))
+
+		if task.Description != "" {
+			line("DESCRIPTION", escapeText(task.Description))
+		}
+
+		if component == ComponentTodo {
+			line("DUE", deadline)
+			line("STATUS", todoStatus(task.Status))
+
+			if task.Status == domain.Done {
+				line("PERCENT-COMPLETE", "100")
+
+				if task.CompletedAt != nil {
+					line("COMPLETED", task.CompletedAt.UTC().Format(icsTimeFormat))
+				}
+			}
+		} else {
+			// tanpa DTEND dan DURATION, event DATE-TIME berakhir di DTSTART (RFC 5545 3.6.1),
+			// DTEND yang sama dengan DTSTART tidak valid karena DTEND harus setelah DTSTART
+			line("DTSTART", deadline)
+			line("TRANSP", "TRANSPARENT")
+		}
+
+		line("END", string(component))
+	}
+
+	line("END", "VCALENDAR")
+
+	return bw.Flush()
+}
+
+func todoStatus(status domain.TaskStatus) string {
+	switch status {
+	case domain.InProgress:
+		return "IN-PROCESS"
+	case domain.Done:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// writeLine menulis satu content line dan melipatnya setiap 75 octet tanpa memotong karakter UTF-8.
+// Baris lanjutan diawali satu spasi sehingga isinya maksimal 74 octet.
+func writeLine(w *bufio.Writer, s string) {
+	limit := maxLineLength
+
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineLength - 1
+	}
+
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,55 @@
+package calendar
+
+import (
+	"strings"
+	"task-management/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestEncodeEventHasNoEndTime(t *testing.T) {
+	deadline := time.Date(2026, 10, 20, 9, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+	tasks := []domain.Task{
+		{ID: 1, Title: "Report; draft, v2", Status: domain.ToDo, Deadline: &deadline},
+		{ID: 2, Title: "Done", Status: domain.Done, Deadline: &deadline},
+		{ID: 3, Title: "No deadline", Status: domain.ToDo},
+	}
+
+	var out strings.Builder
+	if err := Encode(&out, tasks, ComponentEvent, deadline); err != nil {
+		t.Fatal(err)
+	}
+
+	ics := out.String()
+
+	for _, want := range []string{"DTSTART:20261020T023000Z\r\n", `SUMMARY:Report\; draft\, v2` + "\r\n"} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("feed does not contain %q:\n%s", want, ics)
+		}
+	}
+
+	if strings.Contains(ics, "DTEND") {
+		t.Errorf("event has DTEND equal to DTSTART:\n%s", ics)
+	}
+
+	if got := strings.Count(ics, "BEGIN:VEVENT"); got != 1 {
+		t.Errorf("feed has %d events, want only the open task with a deadline", got)
+	}
+}
+
+func TestEncodeTodoMarksCompleted(t *testing.T) {
+	deadline := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	completed := deadline.Add(-time.Hour)
+	tasks := []domain.Task{{ID: 1, Title: "Done", Status: domain.Done, Deadline: &deadline, CompletedAt: &completed}}
+
+	var out strings.Builder
+	if err := Encode(&out, tasks, ComponentTodo, deadline); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"BEGIN:VTODO", "DUE:20261020T000000Z", "STATUS:COMPLETED", "COMPLETED:20261019T230000Z"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("todo does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
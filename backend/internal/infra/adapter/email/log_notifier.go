@@ -0,0 +1,48 @@
+package email
+
+import (
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+
+	"go.uber.org/zap"
+)
+
+// LogNotifier dipakai saat SMTP belum dikonfigurasi, email hanya dicatat di log.
+type LogNotifier struct{}
+
+func NewLogNotifier() services.Notifier {
+	return &LogNotifier{}
+}
+
+// SendTaskAssigned implements services.Notifier.
+func (l *LogNotifier) SendTaskAssigned(user *domain.User, task *domain.Task) error {
+	logger.Info("email skipped: task assigned", zap.Uint("user_id", user.ID), zap.Uint("task_id", task.ID))
+	return nil
+}
+
+// SendDeadlineReminder implements services.Notifier.
+func (l *LogNotifier) SendDeadlineReminder(user *domain.User, task *domain.Task) error {
+	logger.Info("email skipped: deadline reminder", zap.Uint("user_id", user.ID), zap.Uint("task_id", task.ID))
+	return nil
+}
+
+// SendDigest implements services.Notifier.
+func (l *LogNotifier) SendDigest(user *domain.User, digest *domain.Digest) error {
+	logger.Info("email skipped: digest", zap.Uint("user_id", user.ID), zap.String("frequency", string(digest.Frequency)))
+	return nil
+}
+
+// SendPasswordReset implements services.Notifier.
+func (l *LogNotifier) SendPasswordReset(user *domain.User, reset *domain.PasswordReset) error {
+	// token tidak ditulis ke log, tanpa SMTP reset password tidak bisa diselesaikan
+	logger.Warn("email skipped: password reset", zap.Uint("user_id", user.ID))
+	return nil
+}
+
+// SendEmailVerification implements services.Notifier.
+func (l *LogNotifier) SendEmailVerification(user *domain.User, verification *domain.EmailVerification) error {
+	// token tidak ditulis ke log, tanpa SMTP email baru tidak bisa dikonfirmasi
+	logger.Warn("email skipped: email verification", zap.Uint("user_id", user.ID))
+	return nil
+}
@@ -0,0 +1,97 @@
+package email
+
+import (
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/utils"
+)
+
+type SMTPNotifier struct {
+	cfg       config.SMTPConfig
+	templates *templates
+}
+
+// NewSMTPNotifier membuat notifier email. Server tanpa autentikasi (mis. MailHog)
+// cukup dikonfigurasi dengan host dan port saja.
+func NewSMTPNotifier(cfg config.SMTPConfig) (services.Notifier, error) {
+	tpl, err := loadTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email templates: %w", err)
+	}
+
+	return &SMTPNotifier{
+		cfg:       cfg,
+		templates: tpl,
+	}, nil
+}
+
+// SendTaskAssigned implements services.Notifier.
+func (s *SMTPNotifier) SendTaskAssigned(user *domain.User, task *domain.Task) error {
+	data := map[string]any{"User": user, "Task": task}
+	return s.send(user, "Task assigned: "+task.Title, templateTaskAssigned, data)
+}
+
+// SendDeadlineReminder implements services.Notifier.
+func (s *SMTPNotifier) SendDeadlineReminder(user *domain.User, task *domain.Task) error {
+	data := map[string]any{"User": user, "Task": task}
+	return s.send(user, "Deadline approaching: "+task.Title, templateDeadlineReminder, data)
+}
+
+// SendDigest implements services.Notifier.
+func (s *SMTPNotifier) SendDigest(user *domain.User, digest *domain.Digest) error {
+	data := map[string]any{"User": user, "Digest": digest}
+	return s.send(user, fmt.Sprintf("Your %s task digest", digest.Frequency), templateDigest, data)
+}
+
+// SendPasswordReset implements services.Notifier.
+func (s *SMTPNotifier) SendPasswordReset(user *domain.User, reset *domain.PasswordReset) error {
+	data := map[string]any{"User": user, "Reset": reset}
+	return s.send(user, "Reset your password", templatePasswordReset, data)
+}
+
+// SendEmailVerification implements services.Notifier.
+func (s *SMTPNotifier) SendEmailVerification(user *domain.User, verification *domain.EmailVerification) error {
+	recipient := *user
+	recipient.Email = verification.Email
+
+	data := map[string]any{"User": user, "Verification": verification}
+	return s.send(&recipient, "Confirm your email address", templateEmailVerification, data)
+}
+
+func (s *SMTPNotifier) send(user *domain.User, subject, templateName string, data map[string]any) error {
+	if user.Email == "" {
+		return fmt.Errorf("user %d has no email address", user.ID)
+	}
+
+	// tanggal di template ditampilkan dalam zona waktu user
+	data["Location"] = utils.LocationOrUTC(user.Timezone)
+
+	text, html, err := s.templates.render(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s template: %w", templateName, err)
+	}
+
+	msg, err := buildMessage(s.cfg.From, user.Email, subject, text, html)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+
+	// header From boleh berisi nama tampilan, envelope MAIL FROM hanya alamatnya
+	sender, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid smtp.from: %w", err)
+	}
+
+	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
+
+	return smtp.SendMail(addr, auth, sender.Address, []string{user.Email}, msg)
+}
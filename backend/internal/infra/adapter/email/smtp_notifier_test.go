@@ -0,0 +1,256 @@
+package email
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"testing"
+	"time"
+)
+
+// smtpSink adalah server SMTP minimal seperti MailHog yang menyimpan satu email per koneksi.
+type smtpSink struct {
+	listener net.Listener
+	messages chan sinkMessage
+}
+
+type sinkMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSMTPSink(t *testing.T) *smtpSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sink := &smtpSink{listener: listener, messages: make(chan sinkMessage, 1)}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go sink.serve()
+	return sink
+}
+
+func (s *smtpSink) config() config.SMTPConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return config.SMTPConfig{Host: addr.IP.String(), Port: addr.Port, From: "Task Management <noreply@example.com>"}
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+
+	var message sinkMessage
+	reply("220 sink ready")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.TrimSpace(line)
+		upper := strings.ToUpper(command)
+
+		switch {
+		case strings.HasPrefix(upper, "EHLO"), strings.HasPrefix(upper, "HELO"):
+			reply("250 sink")
+		case strings.HasPrefix(upper, "MAIL FROM:"):
+			message.from = strings.Trim(command[len("MAIL FROM:"):], "<> ")
+			reply("250 ok")
+		case strings.HasPrefix(upper, "RCPT TO:"):
+			message.to = append(message.to, strings.Trim(command[len("RCPT TO:"):], "<> "))
+			reply("250 ok")
+		case upper == "DATA":
+			reply("354 end with .")
+
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if line == ".\r\n" {
+					break
+				}
+
+				data.WriteString(strings.TrimPrefix(line, "."))
+			}
+
+			message.data = data.String()
+			s.messages <- message
+			reply("250 queued")
+		case upper == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func (s *smtpSink) receive(t *testing.T) sinkMessage {
+	t.Helper()
+
+	select {
+	case message := <-s.messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email received")
+		return sinkMessage{}
+	}
+}
+
+// readParts mengembalikan isi bagian multipart yang sudah di-decode per content type.
+func readParts(t *testing.T, message *mail.Message) map[string]string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q, %v, want multipart/alternative", message.Header.Get("Content-Type"), err)
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// NextPart sudah men-decode quoted-printable dan menghapus header-nya
+		body, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(body)
+	}
+}
+
+func TestSMTPNotifierSendsTaskAssigned(t *testing.T) {
+	sink := newSMTPSink(t)
+
+	notifier, err := NewSMTPNotifier(sink.config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Name: "Alice", Email: "alice@example.com", Timezone: "Asia/Jakarta"}
+	task := &domain.Task{ID: 7, Title: "Prepare the ünïcode report", Status: domain.ToDo, Deadline: &deadline}
+
+	if err := notifier.SendTaskAssigned(user, task); err != nil {
+		t.Fatalf("SendTaskAssigned: %v", err)
+	}
+
+	received := sink.receive(t)
+	if received.from != "noreply@example.com" {
+		t.Errorf("envelope from = %q, want the bare address", received.from)
+	}
+
+	if len(received.to) != 1 || received.to[0] != "alice@example.com" {
+		t.Errorf("envelope recipients = %v, want alice@example.com", received.to)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(received.data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || subject != "Task assigned: Prepare the ünïcode report" {
+		t.Errorf("subject = %q, %v", subject, err)
+	}
+
+	if from, to := message.Header.Get("From"), message.Header.Get("To"); from != "Task Management <noreply@example.com>" || to != "alice@example.com" {
+		t.Errorf("From = %q, To = %q", from, to)
+	}
+
+	if message.Header.Get("MIME-Version") != "1.0" || message.Header.Get("Date") == "" {
+		t.Errorf("headers = %v, want MIME-Version and Date", message.Header)
+	}
+
+	parts := readParts(t, message)
+
+	// deadline ditampilkan di zona waktu penerima
+	text := parts["text/plain"]
+	if !strings.Contains(text, "Hi Alice,") || !strings.Contains(text, "Prepare the ünïcode report") || !strings.Contains(text, "01 Mar 2026 17:00 WIB") {
+		t.Errorf("text body = %q", text)
+	}
+
+	if html := parts["text/html"]; !strings.Contains(html, "<td>Prepare the ünïcode report</td>") {
+		t.Errorf("html body = %q", html)
+	}
+}
+
+func TestSMTPNotifierSendsVerificationToNewAddress(t *testing.T) {
+	sink := newSMTPSink(t)
+
+	notifier, err := NewSMTPNotifier(sink.config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &domain.User{ID: 1, Name: "Alice", Email: "old@example.com"}
+	verification := &domain.EmailVerification{Email: "new@example.com", Token: "plain-token", ExpiresAt: time.Now().Add(time.Hour)}
+
+	if err := notifier.SendEmailVerification(user, verification); err != nil {
+		t.Fatalf("SendEmailVerification: %v", err)
+	}
+
+	received := sink.receive(t)
+	if len(received.to) != 1 || received.to[0] != "new@example.com" {
+		t.Errorf("envelope recipients = %v, want new@example.com", received.to)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(received.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if text := readParts(t, message)["text/plain"]; !strings.Contains(text, "plain-token") {
+		t.Errorf("text body = %q, want the token", text)
+	}
+}
+
+func TestSMTPNotifierRejectsUserWithoutEmail(t *testing.T) {
+	notifier, err := NewSMTPNotifier(config.SMTPConfig{Host: "127.0.0.1", Port: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := notifier.SendTaskAssigned(&domain.User{ID: 1}, &domain.Task{Title: "x"}); err == nil {
+		t.Error("sent to a user without email")
+	}
+}
@@ -0,0 +1,77 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+const (
+	templateTaskAssigned      = "task_assigned"
+	templateDeadlineReminder  = "deadline_reminder"
+	templateDigest            = "digest"
+	templatePasswordReset     = "password_reset"
+	templateEmailVerification = "email_verification"
+)
+
+const dateLayout = "02 Jan 2006 15:04 MST"
+
+type templates struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+func loadTemplates() (*templates, error) {
+	funcs := map[string]any{"date": formatDate}
+
+	html, err := htmltemplate.New("").Funcs(funcs).ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := texttemplate.New("").Funcs(funcs).ParseFS(templateFS, "templates/*.txt")
+	if err != nil {
+		return nil, err
+	}
+
+	return &templates{html: html, text: text}, nil
+}
+
+// render menghasilkan body plain-text dan HTML dari template dengan nama yang sama.
+func (t *templates) render(name string, data any) (string, string, error) {
+	var text, html bytes.Buffer
+
+	if err := t.text.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return "", "", err
+	}
+
+	if err := t.html.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return "", "", err
+	}
+
+	return text.String(), html.String(), nil
+}
+
+// formatDate menampilkan waktu di zona waktu penerima, lengkap dengan singkatan zonanya.
+func formatDate(v any, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch t := v.(type) {
+	case time.Time:
+		return t.In(loc).Format(dateLayout)
+	case *time.Time:
+		if t == nil {
+			return "-"
+		}
+		return t.In(loc).Format(dateLayout)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AccountHandler struct {
+	accounts services.AccountService
+}
+
+func NewAccountHandler(accounts services.AccountService) *AccountHandler {
+	return &AccountHandler{accounts: accounts}
+}
+
+// Delete godoc
+// @Summary Delete own account
+// @Description Delete the authenticated user's account according to the server's deletion policy: "delete" removes the user with all tasks and data, "anonymize" keeps the tasks under an anonymized, deactivated user. Notifications, sessions, tokens, SSO links, login audit entries for the username and the avatar are always removed. The current password is required unless the account only uses SSO.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.DeleteAccount false "Current password"
+// @Success 200 {object} response.MessageResponse "Account deleted"
+// @Failure 400 {object} response.ErrorResponse "Password is incorrect"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "The last admin account cannot be deleted"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile [delete]
+func (h *AccountHandler) Delete(c *gin.Context) {
+	var req request.DeleteAccount
+
+	// body boleh kosong untuk akun SSO tanpa password
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.accounts.DeleteAccount(userClaims.UserID, req.Password); err != nil {
+		switch err.Error() {
+		case "invalid password":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Password is incorrect",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		case "the last admin account cannot be deleted":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusConflict,
+				Error:   "The last admin account cannot be deleted, promote another admin first",
+			}
+
+			c.JSON(http.StatusConflict, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to delete account: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Account deleted",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Export godoc
+// @Summary Export personal data
+// @Description Download everything stored about the authenticated user as a ZIP archive of JSON files: profile, tasks, notifications, notification preferences, login sessions (activity), API tokens, SSO identities and failed login attempts (login_audits.json, with IP address), plus the avatar if there is one. Secrets such as password and token hashes are never included.
+// @Tags profile
+// @Produce application/zip
+// @Security BearerAuth
+// @Success 200 {file} file "ZIP archive"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/export [get]
+func (h *AccountHandler) Export(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	data, err := h.accounts.ExportData(userClaims.UserID)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to export personal data: ", zap.Error(err))
+		return
+	}
+
+	filename := fmt.Sprintf("personal-data-%d-%s.zip", data.User.ID, data.ExportedAt.Format("20060102-150405"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	// header sudah terkirim, error saat menulis zip hanya bisa dicatat
+	if err := writePersonalData(c.Writer, data); err != nil {
+		logger.Error("failed to write personal data export: ", zap.Error(err))
+		_ = c.Error(err)
+	}
+}
+
+// writePersonalData menulis satu file JSON per jenis data, list kosong ditulis sebagai [].
+func writePersonalData(w io.Writer, data *domain.PersonalData) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", data.User},
+		{"tasks.json", orEmpty(data.Tasks)},
+		{"notifications.json", orEmpty(data.Notifications)},
+		{"notification_preferences.json", data.NotificationPreference},
+		{"sessions.json", orEmpty(data.Sessions)},
+		{"api_tokens.json", orEmpty(data.APITokens)},
+		{"identities.json", orEmpty(data.Identities)},
+		{"login_audits.json", orEmpty(data.LoginAudits)},
+	}
+
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: data.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(file.value); err != nil {
+			return err
+		}
+	}
+
+	if data.Avatar != nil {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     "avatar.png",
+			Method:   zip.Store,
+			Modified: data.Avatar.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := f.Write(data.Avatar.Data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func orEmpty[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,421 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AdminHandler struct {
+	admin services.AdminService
+}
+
+func NewAdminHandler(admin services.AdminService) *AdminHandler {
+	return &AdminHandler{admin: admin}
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description List all users with their role, status and number of tasks. Requires the admin scope.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search in username, name and email"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size, max 100 (default 20)"
+// @Success 200 {object} response.ListAdminUserResponse "Users"
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Admin scope required"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/users [get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var req request.ListUsers
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	users, total, err := h.admin.ListUsers(req.Query, req.Page, req.Limit)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to list users: ", zap.Error(err))
+		return
+	}
+
+	data := make([]response.AdminUser, 0, len(users))
+	for i := range users {
+		data = append(data, toAdminUser(&users[i]))
+	}
+
+	resp := response.ListAdminUserResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    data,
+		Meta: response.Pagination{
+			Page:  req.Page,
+			Limit: req.Limit,
+			Total: total,
+		},
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Get a user with the number of tasks per status. Requires the admin scope.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} response.BaseAdminUserResponse "User"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Admin scope required"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.admin.GetUser(id)
+
+	if err != nil {
+		writeAdminError(c, err, "failed to get user: ")
+		return
+	}
+
+	resp := response.BaseAdminUserResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    toAdminUser(user),
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Deactivate godoc
+// @Summary Deactivate a user
+// @Description Block a user from logging in and revoke all of their sessions. Existing tokens are rejected immediately. Requires the admin scope.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} response.MessageResponse "User deactivated"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID or own account"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Admin scope required"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/deactivate [post]
+func (h *AdminHandler) Deactivate(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.admin.Deactivate(userClaims.UserID, id); err != nil {
+		writeAdminError(c, err, "failed to deactivate user: ")
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "User deactivated",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Reactivate godoc
+// @Summary Reactivate a user
+// @Description Allow a deactivated user to log in again. Requires the admin scope.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} response.MessageResponse "User reactivated"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Admin scope required"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/reactivate [post]
+func (h *AdminHandler) Reactivate(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.admin.Reactivate(userClaims.UserID, id); err != nil {
+		writeAdminError(c, err, "failed to reactivate user: ")
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "User reactivated",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Delete godoc
+// @Summary Delete a user
+// @Description Delete a user according to profile.deletion_policy: "delete" removes the user with their tasks, notifications, tokens, sessions and avatar, "anonymize" keeps an anonymized user and their tasks. Requires the admin scope.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} response.MessageResponse "User deleted"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID or own account"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Admin scope required"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/users/{id} [delete]
+func (h *AdminHandler) Delete(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.admin.DeleteUser(userClaims.UserID, id); err != nil {
+		writeAdminError(c, err, "failed to delete user: ")
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "User deleted",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResetPassword godoc
+// @Summary Reset a user's password
+// @Description Set a new password for a user and revoke all of their sessions. Without new_password a temporary password is generated and returned once. Requires the admin scope.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body request.AdminResetPassword false "New password"
+// @Success 200 {object} response.BaseAdminResetPasswordResponse "Password reset"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID or password does not meet the policy"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Admin scope required"
+// @Failure 404 {object} response.ErrorResponse "User not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/users/{id}/reset-password [post]
+func (h *AdminHandler) ResetPassword(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req request.AdminResetPassword
+
+	// body boleh kosong
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   err.Error(),
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+	}
+
+	temporary, err := h.admin.ResetPassword(id, req.NewPassword)
+
+	if err != nil {
+		writeAdminError(c, err, "failed to reset user password: ")
+		return
+	}
+
+	resp := response.BaseAdminResetPasswordResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data: response.AdminResetPassword{
+			TemporaryPassword: temporary,
+		},
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toAdminUser(overview *domain.UserOverview) response.AdminUser {
+	user := overview.User
+
+	var byStatus map[string]int64
+	if overview.Tasks.ByStatus != nil {
+		byStatus = make(map[string]int64, len(overview.Tasks.ByStatus))
+		for status, count := range overview.Tasks.ByStatus {
+			byStatus[string(status)] = count
+		}
+	}
+
+	return response.AdminUser{
+		ID:               user.ID,
+		Name:             user.Name,
+		Username:         user.Username,
+		Email:            user.Email,
+		Role:             string(user.Role),
+		TwoFactorEnabled: user.TOTPEnabled,
+		Active:           user.IsActive(),
+		DeactivatedAt:    user.DeactivatedAt,
+		CreatedAt:        user.CreatedAt,
+		Tasks: response.TaskCounts{
+			Total:    overview.Tasks.Total,
+			ByStatus: byStatus,
+		},
+	}
+}
+
+// userIDParam membaca :id dari path, menulis 400 jika tidak valid.
+func userIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid user ID",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+func writeAdminError(c *gin.Context, err error, logMessage string) {
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   policyErr.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	switch err.Error() {
+	case "user not found":
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusNotFound,
+			Error:   "User not found",
+		}
+
+		c.JSON(http.StatusNotFound, resp)
+		return
+	case "admins cannot deactivate or delete their own account":
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Admins cannot deactivate or delete their own account",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	resp := response.ErrorResponse{
+		Success: false,
+		Code:    http.StatusInternalServerError,
+		Error:   "Internal server error",
+	}
+
+	c.JSON(http.StatusInternalServerError, resp)
+
+	logger.Error(logMessage, zap.Error(err))
+}
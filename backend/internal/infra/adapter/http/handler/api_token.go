@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type APITokenHandler struct {
+	tokenService services.APITokenService
+}
+
+func NewAPITokenHandler(tokenService services.APITokenService) *APITokenHandler {
+	return &APITokenHandler{tokenService: tokenService}
+}
+
+// Create godoc
+// @Summary Create a personal access token
+// @Description Create a personal access token for scripts and integrations. The token is only shown once in this response. Send it as "Authorization: Bearer <token>".
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param token body request.CreateAPIToken true "Token name, scopes and optional expiry"
+// @Success 201 {object} response.BaseCreatedAPITokenResponse "Token created"
+// @Failure 400 {object} response.ErrorResponse "Bad request - invalid JSON or validation error"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Requested scope is not held by the current token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/tokens [post]
+func (h *APITokenHandler) Create(c *gin.Context) {
+	var req request.CreateAPIToken
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	// token baru tidak boleh punya akses lebih luas dari token yang membuatnya
+	for _, scope := range req.Scopes {
+		if !userClaims.HasScope(scope) {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusForbidden,
+				Error:   "Cannot grant scope " + scope,
+			}
+
+			c.JSON(http.StatusForbidden, resp)
+			return
+		}
+	}
+
+	plain, token, err := h.tokenService.CreateToken(userClaims.UserID, req.Name, req.Scopes, req.ExpiresAt)
+
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid scope") || err.Error() == "expiry must be in the future" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   err.Error(),
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to create api token: ", zap.Error(err))
+		return
+	}
+
+	resp := response.BaseCreatedAPITokenResponse{
+		Success: true,
+		Code:    http.StatusCreated,
+		Data: response.CreatedAPIToken{
+			Token: plain,
+			Info:  toAPITokenResponse(token),
+		},
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// List godoc
+// @Summary List personal access tokens
+// @Description List the personal access tokens of the authenticated user, including revoked and expired ones. The token values are never returned.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.ListAPITokenResponse "Successfully retrieved tokens"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/tokens [get]
+func (h *APITokenHandler) List(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	tokens, err := h.tokenService.ListTokens(userClaims.UserID)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Info("failed to list api tokens: ", zap.Error(err))
+		return
+	}
+
+	data := make([]response.APIToken, 0, len(tokens))
+	for i := range tokens {
+		data = append(data, toAPITokenResponse(&tokens[i]))
+	}
+
+	resp := response.ListAPITokenResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    data,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Revoke godoc
+// @Summary Revoke a personal access token
+// @Description Revoke a personal access token of the authenticated user. The token stops working immediately.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Param id path int true "Token ID"
+// @Security BearerAuth
+// @Success 200 {object} response.MessageResponse "Token revoked"
+// @Failure 400 {object} response.ErrorResponse "Invalid token ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Token not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/tokens/{id} [delete]
+func (h *APITokenHandler) Revoke(c *gin.Context) {
+	idParam := c.Param("id")
+
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid token ID",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.tokenService.RevokeToken(uint(id), userClaims.UserID); err != nil {
+		if err.Error() == "token not found" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusNotFound,
+				Error:   "Token not found",
+			}
+
+			c.JSON(http.StatusNotFound, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to revoke api token: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Token revoked",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func toAPITokenResponse(token *domain.APIToken) response.APIToken {
+	return response.APIToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Prefix:     token.Prefix,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// creatingTokens mencatat scope token yang dibuat.
+type creatingTokens struct {
+	services.APITokenService
+	created [][]string
+}
+
+func (c *creatingTokens) CreateToken(userID uint, name string, scopes []string, expiresAt *time.Time) (string, *domain.APIToken, error) {
+	c.created = append(c.created, scopes)
+	return domain.APITokenPrefix + "secret", &domain.APIToken{ID: 1, UserID: userID, Name: name, Prefix: "tmp_secret", Scopes: scopes}, nil
+}
+
+func tokenEngine(tokens services.APITokenService, scopes []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.POST("/profile/tokens", func(c *gin.Context) {
+		c.Set("user", &domain.JWTClaims{UserID: 1, Scopes: scopes})
+	}, NewAPITokenHandler(tokens).Create)
+
+	return engine
+}
+
+func TestCreateAPITokenScopeSubset(t *testing.T) {
+	tests := []struct {
+		name   string
+		held   []string
+		scopes string
+		want   int
+	}{
+		{"same scopes", []string{domain.ScopeTasksRead, domain.ScopeProfile}, `["tasks:read","profile"]`, http.StatusCreated},
+		{"write implies read", []string{domain.ScopeTasksWrite}, `["tasks:read"]`, http.StatusCreated},
+		{"read cannot grant write", []string{domain.ScopeTasksRead}, `["tasks:read","tasks:write"]`, http.StatusForbidden},
+		{"user cannot grant admin", domain.DefaultUserScopes, `["admin"]`, http.StatusForbidden},
+		{"unknown scope", domain.DefaultUserScopes, `["tasks:delete"]`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := &creatingTokens{}
+
+			body := `{"name":"cron","scopes":` + tt.scopes + `}`
+			req := httptest.NewRequest(http.MethodPost, "/profile/tokens", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			tokenEngine(tokens, tt.held).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, body = %q, want %d", rec.Code, rec.Body.String(), tt.want)
+			}
+
+			if created := len(tokens.created) == 1; created != (tt.want == http.StatusCreated) {
+				t.Errorf("created = %v, want a token only for status 201", tokens.created)
+			}
+
+			if tt.want == http.StatusCreated && !strings.Contains(rec.Body.String(), `"token":"tmp_secret"`) {
+				t.Errorf("body = %q, want the plain token once", rec.Body.String())
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"task-management/internal/applications/dto/request"
 	"task-management/internal/applications/dto/response"
 	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
 	"task-management/internal/infra/adapter/http/middleware"
 	"task-management/internal/infra/logger"
 
@@ -22,13 +27,14 @@ func NewAuthHandler(auth services.AuthService) *AuthHandler {
 
 // Register godoc
 // @Summary Register a new user
-// @Description Register a new user with name, username, and password
+// @Description Register a new user with name, username, password and an optional email address
+// @Description The email address is saved after it is confirmed through the link sent to it, until then the response has no email
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body request.RegisterUser true "User registration data"
 // @Success 201 {object} response.BaseUserResponse "User registered successfully"
-// @Failure 400 {object} response.ErrorResponse "Bad request - invalid input"
+// @Failure 400 {object} response.ErrorResponse "Bad request - invalid input, reserved username or password does not meet the policy"
 // @Failure 409 {object} response.ErrorResponse "Conflict - username already exists"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /auth/register [post]
@@ -45,7 +51,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	user, err := h.auth.Register(req.Name, req.Username, req.Password)
+	user, err := h.auth.Register(req.Name, req.Username, req.Email, req.Password)
 
 	if err != nil {
 		if err.Error() == "username is already exists" {
@@ -58,6 +64,27 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "username is reserved" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Usernames starting with \"" + domain.DeletedUsernamePrefix + "\" are reserved",
+			}
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		var policyErr *domain.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   policyErr.Error(),
+			}
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
 		resp := response.ErrorResponse{
 			Success: false,
 			Code:    http.StatusInternalServerError,
@@ -76,6 +103,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			ID:       user.ID,
 			Name:     user.Name,
 			Username: user.Username,
+			Email:    user.Email,
 		},
 	}
 
@@ -84,7 +112,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate user with username and password
+// @Description Authenticate user with username and password. If the user has 2FA enabled, the response contains two_factor_required and a short-lived challenge_token instead of an access token.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -92,7 +120,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} response.BaseAuthResponse "success: true, code: 200, data: response.AuthResponse"
 // @Failure 400 {object} response.ErrorResponse "success: false, code: 400, error: validation error"
 // @Failure 401 {object} response.ErrorResponse "success: false, code: 401, error: Invalid username or password"
+// @Failure 403 {object} response.ErrorResponse "success: false, code: 403, error: Account is deactivated"
 // @Failure 404 {object} response.ErrorResponse "success: false, code: 404, error: User not found"
+// @Failure 429 {object} response.ErrorResponse "success: false, code: 429, error: Too many failed login attempts"
 // @Failure 500 {object} response.ErrorResponse "success: false, code: 500, error: Internal server error"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -108,10 +138,14 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, user, err := h.auth.Login(req.Username, req.Password)
+	result, err := h.auth.Login(req.Username, req.Password, clientInfo(c))
 
 	if err != nil {
-		if err.Error() == "invalid username or password" {
+		if writeThrottled(c, err) {
+			return
+		}
+
+		if err.Error() == "invalid username or password" || err.Error() == "invalid password" {
 			resp := response.ErrorResponse{
 				Success: false,
 				Code:    http.StatusUnauthorized,
@@ -121,6 +155,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 			return
 		}
 
+		if err.Error() == "account is deactivated" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusForbidden,
+				Error:   "Account is deactivated",
+			}
+			c.JSON(http.StatusForbidden, resp)
+			return
+		}
+
 		if err.Error() == "user not found" {
 			resp := response.ErrorResponse{
 				Success: false,
@@ -142,20 +186,148 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp := response.BaseAuthResponse{
+	// 2FA aktif: client harus mengirim kode ke /auth/login/2fa bersama challenge token
+	if result.TwoFactorRequired {
+		resp := response.BaseAuthResponse{
+			Success: true,
+			Code:    http.StatusOK,
+			Data: response.AuthResponse{
+				TwoFactorRequired: true,
+				ChallengeToken:    result.Token,
+			},
+		}
+
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthResponse(result))
+}
+
+// LoginTwoFactor godoc
+// @Summary Complete login with a 2FA code
+// @Description Exchange the challenge token returned by /auth/login and a TOTP or recovery code for an access token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.LoginTwoFactor true "Challenge token and code"
+// @Success 200 {object} response.BaseAuthResponse "success: true, code: 200, data: response.AuthResponse"
+// @Failure 400 {object} response.ErrorResponse "success: false, code: 400, error: validation error"
+// @Failure 401 {object} response.ErrorResponse "success: false, code: 401, error: Invalid or expired challenge token, or invalid code"
+// @Failure 403 {object} response.ErrorResponse "success: false, code: 403, error: Account is deactivated"
+// @Failure 429 {object} response.ErrorResponse "success: false, code: 429, error: Too many failed login attempts"
+// @Failure 500 {object} response.ErrorResponse "success: false, code: 500, error: Internal server error"
+// @Router /auth/login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req request.LoginTwoFactor
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	result, err := h.auth.VerifyTwoFactor(req.ChallengeToken, req.Code, clientInfo(c))
+
+	if err != nil {
+		if writeThrottled(c, err) {
+			return
+		}
+
+		switch err.Error() {
+		case "account is deactivated":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusForbidden,
+				Error:   "Account is deactivated",
+			}
+			c.JSON(http.StatusForbidden, resp)
+			return
+		case "invalid two-factor code", "invalid token", "token expired", "invalid token claims", "invalid challenge token", "user not found":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusUnauthorized,
+				Error:   "Invalid or expired challenge token, or invalid code",
+			}
+			c.JSON(http.StatusUnauthorized, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+		logger.Info("failed to verify two-factor login: ", zap.Error(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthResponse(result))
+}
+
+func toAuthResponse(result *domain.LoginResult) response.BaseAuthResponse {
+	return response.BaseAuthResponse{
 		Success: true,
 		Code:    http.StatusOK,
 		Data: response.AuthResponse{
-			Token: token,
-			User: &response.UserResponse{
-				ID:       user.ID,
-				Name:     user.Name,
-				Username: user.Username,
-			},
+			Token: result.Token,
+			User:  toUserResponse(result.User),
 		},
 	}
+}
 
-	c.JSON(http.StatusOK, resp)
+func toUserResponse(user *domain.User) *response.UserResponse {
+	resp := &response.UserResponse{
+		ID:               user.ID,
+		Name:             user.Name,
+		Username:         user.Username,
+		Email:            user.Email,
+		EmailVerified:    user.Email != "" && user.EmailVerifiedAt != nil,
+		Timezone:         user.Timezone,
+		Locale:           user.Locale,
+		Role:             string(user.Role),
+		TwoFactorEnabled: user.TOTPEnabled,
+	}
+
+	// versi di query string membuat cache browser langsung basi saat avatar diganti
+	if user.AvatarUpdatedAt != nil {
+		resp.AvatarURL = fmt.Sprintf("/api/v1/users/%d/avatar?v=%d", user.ID, user.AvatarUpdatedAt.Unix())
+	}
+
+	return resp
+}
+
+// clientInfo mengambil IP dan user agent untuk dicatat di session.
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// writeThrottled menulis 429 dengan header Retry-After jika login sedang dikunci.
+func writeThrottled(c *gin.Context, err error) bool {
+	var throttled *domain.LoginThrottledError
+	if !errors.As(err, &throttled) {
+		return false
+	}
+
+	retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+	resp := response.ErrorResponse{
+		Success: false,
+		Code:    http.StatusTooManyRequests,
+		Error:   "Too many failed login attempts, try again later",
+	}
+	c.JSON(http.StatusTooManyRequests, resp)
+	return true
 }
 
 // Me godoc
@@ -200,11 +372,7 @@ func (h *AuthHandler) Me(c *gin.Context) {
 	resp := response.BaseUserResponse{
 		Success: true,
 		Code:    http.StatusOK,
-		Data: response.UserResponse{
-			ID:       user.ID,
-			Name:     user.Name,
-			Username: user.Username,
-		},
+		Data:    *toUserResponse(user),
 	}
 
 	c.JSON(http.StatusOK, resp)
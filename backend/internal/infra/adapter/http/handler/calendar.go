@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/infra/adapter/calendar"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CalendarHandler struct {
+	calendarService services.CalendarService
+}
+
+func NewCalendarHandler(calendarService services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// Feed godoc
+// @Summary ICS feed of task deadlines
+// @Description Public iCalendar feed of every task with a deadline, authenticated by the feed token in the URL instead of a Bearer header.
+// @Description By default tasks are published as VEVENT and Done tasks are left out. With type=todo tasks are published as VTODO and Done tasks are marked completed.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally with the .ics suffix"
+// @Param type query string false "Component type" Enums(event, todo) default(event)
+// @Success 200 {file} file "iCalendar feed"
+// @Failure 404 {object} response.ErrorResponse "Calendar feed not found or its owner is deactivated"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /calendar/{token} [get]
+func (h *CalendarHandler) Feed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	tasks, err := h.calendarService.GetFeedTasks(token)
+
+	if err != nil {
+		if err.Error() == "calendar feed not found" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusNotFound,
+				Error:   "Calendar feed not found",
+			}
+
+			c.JSON(http.StatusNotFound, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Info("failed to get calendar feed: ", zap.Error(err))
+		return
+	}
+
+	component := calendar.ComponentEvent
+	if c.Query("type") == "todo" {
+		component = calendar.ComponentTodo
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", `inline; filename="tasks.ics"`)
+	c.Status(http.StatusOK)
+
+	if err := calendar.Encode(c.Writer, tasks, component, time.Now()); err != nil {
+		logger.Error("failed to write calendar feed: ", zap.Error(err))
+	}
+}
+
+// CreateToken godoc
+// @Summary Create calendar feed token
+// @Description Create a new calendar feed token for the authenticated user. Any previous token stops working.
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} response.BaseCalendarFeedResponse "Feed token created"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/calendar-token [post]
+func (h *CalendarHandler) CreateToken(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	token, err := h.calendarService.CreateFeedToken(userClaims.UserID)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to create calendar token: ", zap.Error(err))
+		return
+	}
+
+	resp := response.BaseCalendarFeedResponse{
+		Success: true,
+		Code:    http.StatusCreated,
+		Data: response.CalendarFeed{
+			Token: token,
+			URL:   feedURL(c, token),
+		},
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// RevokeToken godoc
+// @Summary Revoke calendar feed token
+// @Description Revoke the calendar feed token of the authenticated user
+// @Tags calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.MessageResponse "Feed token revoked"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/calendar-token [delete]
+func (h *CalendarHandler) RevokeToken(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.calendarService.RevokeFeedToken(userClaims.UserID); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to revoke calendar token: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Calendar feed token revoked",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// feedURL membangun URL feed absolut berdasarkan host dari request.
+func feedURL(c *gin.Context, token string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s/api/v1/calendar/%s.ics", scheme, c.Request.Host, token)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/adapter/importer"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ImportHandler struct {
+	importService services.ImportService
+}
+
+func NewImportHandler(importService services.ImportService) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// Import godoc
+// @Summary Import tasks from Trello, Jira or GitHub
+// @Description Import tasks from an uploaded export file: Trello board JSON, Jira CSV or GitHub Issues JSON. No external API is called.
+// @Description Re-importing the same file updates the tasks created earlier instead of creating duplicates.
+// @Description The optional mapping field is a JSON object {"statuses": {...}, "labels": {...}, "assignees": {...}} that maps external values to local statuses, labels and usernames.
+// @Description Tasks belong to the importing user unless an external assignee is listed in the assignees mapping; only admins may map assignees to other users, otherwise the item is reported as failed.
+// @Tags tasks
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param source path string true "Export source" Enums(trello, jira, github)
+// @Param file formData file true "Export file"
+// @Param mapping formData string false "Mapping of external statuses, labels and assignees as JSON"
+// @Param dry_run query bool false "Validate and map only, do not save anything"
+// @Success 200 {object} response.BaseExternalImportResponse "Import report"
+// @Failure 400 {object} response.ErrorResponse "Unknown source, missing file or invalid export"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /tasks/import/{source} [post]
+func (h *ImportHandler) Import(c *gin.Context) {
+	source := c.Param("source")
+
+	adapter, ok := importer.Get(source)
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Unknown import source. Use trello, jira or github.",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	var mapping domain.ImportMapping
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Invalid mapping: " + err.Error(),
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Export file is required",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Export file is not readable",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+	defer f.Close()
+
+	tasks, err := adapter.Parse(f, middleware.GetLocation(c))
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	report, err := h.importService.ImportExternal(userClaims.UserID, source, tasks, mapping, dryRun)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to import external tasks: ", zap.String("source", source), zap.Error(err))
+		return
+	}
+
+	result := response.ExternalImportResult{
+		Source:  source,
+		DryRun:  dryRun,
+		Total:   report.Total,
+		Created: report.Created,
+		Updated: report.Updated,
+		Failed:  len(report.Failures),
+		Errors:  []response.ExternalImportError{},
+	}
+
+	for _, failure := range report.Failures {
+		result.Errors = append(result.Errors, response.ExternalImportError{
+			Row:        failure.Index + 1,
+			ExternalID: failure.ExternalID,
+			Error:      failure.Reason,
+		})
+	}
+
+	resp := response.BaseExternalImportResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    result,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
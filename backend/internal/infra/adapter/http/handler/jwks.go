@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+	"task-management/internal/applications/ports/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JWKSHandler struct {
+	jwt services.JWTService
+}
+
+func NewJWKSHandler(jwt services.JWTService) *JWKSHandler {
+	return &JWKSHandler{jwt: jwt}
+}
+
+// Get mengembalikan public key JWT dalam format JWK Set standar (tanpa envelope response)
+// di /.well-known/jwks.json, di luar base path API, supaya bisa dibaca library JWT lain.
+func (h *JWKSHandler) Get(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.jwt.JWKS())
+}
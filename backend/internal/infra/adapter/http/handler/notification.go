@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+}
+
+func NewNotificationHandler(notificationService services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// Get godoc
+// @Summary List notifications
+// @Description Retrieve the notification inbox of the authenticated user, newest first.
+// @Description Types: task_assigned (an import assigned a task to you), task_status_changed (someone else changed the status of a task you own or created) and deadline_approaching (sent once per task).
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only return unread notifications"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size, max 100 (default 20)"
+// @Success 200 {object} response.ListNotificationResponse "Successfully retrieved notifications"
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /notifications [get]
+func (h *NotificationHandler) Get(c *gin.Context) {
+	var req request.ListNotifications
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	notifications, total, err := h.notificationService.GetNotifications(userClaims.UserID, req.Unread, req.Page, req.Limit)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Info("failed to get notifications: ", zap.Error(err))
+		return
+	}
+
+	data := make([]response.Notification, 0, len(notifications))
+
+	loc := middleware.GetLocation(c)
+	for _, n := range notifications {
+		data = append(data, response.Notification{
+			ID:        n.ID,
+			TaskID:    n.TaskID,
+			Type:      string(n.Type),
+			Message:   n.Message,
+			Read:      n.ReadAt != nil,
+			ReadAt:    inLocation(n.ReadAt, loc),
+			CreatedAt: n.CreatedAt.In(loc),
+		})
+	}
+
+	resp := response.ListNotificationResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    data,
+		Meta: response.Pagination{
+			Page:  req.Page,
+			Limit: req.Limit,
+			Total: total,
+		},
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MarkRead godoc
+// @Summary Mark a notification as read
+// @Description Mark a single notification of the authenticated user as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Security BearerAuth
+// @Success 200 {object} response.MessageResponse "Notification marked as read"
+// @Failure 400 {object} response.ErrorResponse "Invalid notification ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "tasks:write scope required"
+// @Failure 404 {object} response.ErrorResponse "Notification not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	idParam := c.Param("id")
+
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid notification ID",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(uint(id), userClaims.UserID); err != nil {
+		// notifikasi milik user lain diperlakukan sebagai not found
+		if err.Error() == "notification not found" || err.Error() == "unauthorized" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusNotFound,
+				Error:   "Notification not found",
+			}
+
+			c.JSON(http.StatusNotFound, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to mark notification as read: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Notification marked as read",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark every unread notification of the authenticated user as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.MessageResponse "All notifications marked as read"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "tasks:write scope required"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.notificationService.MarkAllAsRead(userClaims.UserID); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to mark all notifications as read: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "All notifications marked as read",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Get the email notification preferences of the authenticated user
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.BaseNotificationPreferenceResponse "Successfully retrieved preferences"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/notification-preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	preference, err := h.notificationService.GetPreferences(userClaims.UserID)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Info("failed to get notification preferences: ", zap.Error(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toPreferenceResponse(preference))
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Update the email notification preferences of the authenticated user. Omitted fields are left unchanged.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param preferences body request.UpdateNotificationPreferences true "Notification preferences"
+// @Success 200 {object} response.BaseNotificationPreferenceResponse "Preferences updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request - invalid JSON or validation error"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/notification-preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	var req request.UpdateNotificationPreferences
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	preference, err := h.notificationService.GetPreferences(userClaims.UserID)
+
+	if err == nil {
+		if req.EmailEnabled != nil {
+			preference.EmailEnabled = *req.EmailEnabled
+		}
+
+		if req.TaskAssigned != nil {
+			preference.TaskAssigned = *req.TaskAssigned
+		}
+
+		if req.DeadlineReminder != nil {
+			preference.DeadlineReminder = *req.DeadlineReminder
+		}
+
+		if req.Digest != nil {
+			preference.Digest = *req.Digest
+		}
+
+		err = h.notificationService.UpdatePreferences(preference)
+	}
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to update notification preferences: ", zap.Error(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, toPreferenceResponse(preference))
+}
+
+func toPreferenceResponse(preference *domain.NotificationPreference) response.BaseNotificationPreferenceResponse {
+	return response.BaseNotificationPreferenceResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data: response.NotificationPreference{
+			EmailEnabled:     preference.EmailEnabled,
+			TaskAssigned:     preference.TaskAssigned,
+			DeadlineReminder: preference.DeadlineReminder,
+			Digest:           string(preference.Digest),
+		},
+	}
+}
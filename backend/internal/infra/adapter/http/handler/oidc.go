@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	oidcStateCookie = "oidc_state"
+	oidcCookiePath  = "/api/v1/auth/oidc"
+)
+
+type OIDCHandler struct {
+	oidc            services.OIDCService
+	successRedirect string
+}
+
+func NewOIDCHandler(oidc services.OIDCService, successRedirect string) *OIDCHandler {
+	return &OIDCHandler{
+		oidc:            oidc,
+		successRedirect: successRedirect,
+	}
+}
+
+// Login godoc
+// @Summary Start SSO login
+// @Description Redirect to the OIDC provider (authorization code flow with PKCE). The login state is bound to this browser with a cookie.
+// @Tags auth
+// @Success 302 "Redirect to the OIDC provider"
+// @Failure 500 {object} response.ErrorResponse "Provider discovery failed or internal server error"
+// @Router /auth/oidc/login [get]
+func (h *OIDCHandler) Login(c *gin.Context) {
+	authorization, err := h.oidc.BeginLogin()
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to start oidc login: ", zap.Error(err))
+		return
+	}
+
+	h.setStateCookie(c, authorization.State, 10*60)
+	c.Redirect(http.StatusFound, authorization.URL)
+}
+
+// Callback godoc
+// @Summary Finish SSO login
+// @Description Redirect target of the OIDC provider. Exchanges the code, links the account by subject and creates the user on first login. Returns an access token, or redirects to the configured success URL with the token in the fragment.
+// @Tags auth
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "Login state"
+// @Success 200 {object} response.BaseAuthResponse "success: true, code: 200, data: response.AuthResponse"
+// @Success 302 "Redirect to the success URL"
+// @Failure 400 {object} response.ErrorResponse "Invalid or expired login state"
+// @Failure 401 {object} response.ErrorResponse "Login rejected by the provider or token verification failed"
+// @Failure 403 {object} response.ErrorResponse "Account is deactivated"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Login rejected by provider: " + providerErr,
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	state := c.Query("state")
+	cookieState, err := c.Cookie(oidcStateCookie)
+
+	// state harus sama dengan cookie dari browser yang memulai login (proteksi login CSRF)
+	if err != nil || state == "" || cookieState != state {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid or expired login state",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	h.setStateCookie(c, "", -1)
+
+	result, err := h.oidc.CompleteLogin(state, c.Query("code"), clientInfo(c))
+
+	if err != nil {
+		switch err.Error() {
+		case "invalid or expired login state":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Invalid or expired login state",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		case "oidc login failed":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusUnauthorized,
+				Error:   "SSO login failed",
+			}
+
+			c.JSON(http.StatusUnauthorized, resp)
+			return
+		case "account is deactivated":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusForbidden,
+				Error:   "Account is deactivated",
+			}
+
+			c.JSON(http.StatusForbidden, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to complete oidc login: ", zap.Error(err))
+		return
+	}
+
+	if h.successRedirect != "" {
+		// token di fragment tidak dikirim ke server maupun tercatat di access log
+		c.Redirect(http.StatusFound, h.successRedirect+"#token="+url.QueryEscape(result.Token))
+		return
+	}
+
+	c.JSON(http.StatusOK, toAuthResponse(result))
+}
+
+func (h *OIDCHandler) setStateCookie(c *gin.Context, value string, maxAge int) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+
+	// Lax supaya cookie tetap terkirim saat provider redirect kembali ke callback
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, value, maxAge, oidcCookiePath, "", secure, true)
+}
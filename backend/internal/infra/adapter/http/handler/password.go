@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type PasswordHandler struct {
+	passwords services.PasswordService
+}
+
+func NewPasswordHandler(passwords services.PasswordService) *PasswordHandler {
+	return &PasswordHandler{passwords: passwords}
+}
+
+// Change godoc
+// @Summary Change password
+// @Description Change the password of the authenticated user. All existing login tokens, including the one used for this request, stop working; personal access tokens are not affected.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.ChangePassword true "Current and new password"
+// @Success 200 {object} response.MessageResponse "Password changed"
+// @Failure 400 {object} response.ErrorResponse "Wrong current password or new password does not meet the policy"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/password [put]
+func (h *PasswordHandler) Change(c *gin.Context) {
+	var req request.ChangePassword
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.passwords.ChangePassword(userClaims.UserID, req.CurrentPassword, req.NewPassword); err != nil {
+		if err.Error() == "invalid password" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Current password is incorrect",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		writePasswordError(c, err, "failed to change password: ")
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Password changed, please log in again",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Forgot godoc
+// @Summary Request a password reset
+// @Description Send a single-use, expiring reset token to the email address of the account. The response is the same whether or not the account exists.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.ForgotPassword true "Username"
+// @Success 202 {object} response.MessageResponse "Reset requested"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/password/forgot [post]
+func (h *PasswordHandler) Forgot(c *gin.Context) {
+	var req request.ForgotPassword
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	if err := h.passwords.RequestReset(req.Username); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to request password reset: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusAccepted,
+		Data:    "If the account exists and has an email address, a reset link has been sent",
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// Reset godoc
+// @Summary Reset password
+// @Description Set a new password with a reset token. The token can only be used once and all existing login tokens stop working.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body request.ResetPassword true "Reset token and new password"
+// @Success 200 {object} response.MessageResponse "Password reset"
+// @Failure 400 {object} response.ErrorResponse "Invalid or expired token, or new password does not meet the policy"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/password/reset [post]
+func (h *PasswordHandler) Reset(c *gin.Context) {
+	var req request.ResetPassword
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	if err := h.passwords.ResetPassword(req.Token, req.NewPassword); err != nil {
+		if err.Error() == "invalid or expired reset token" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Invalid or expired reset token",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		writePasswordError(c, err, "failed to reset password: ")
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Password has been reset, please log in",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// writePasswordError menulis 400 untuk pelanggaran password policy, selain itu 500.
+func writePasswordError(c *gin.Context, err error, logMessage string) {
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   policyErr.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	resp := response.ErrorResponse{
+		Success: false,
+		Code:    http.StatusInternalServerError,
+		Error:   "Internal server error",
+	}
+
+	c.JSON(http.StatusInternalServerError, resp)
+
+	logger.Error(logMessage, zap.Error(err))
+}
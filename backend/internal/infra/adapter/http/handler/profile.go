@@ -0,0 +1,395 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ProfileHandler struct {
+	profile services.ProfileService
+}
+
+func NewProfileHandler(profile services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{profile: profile}
+}
+
+// Update godoc
+// @Summary Update profile
+// @Description Update the name, email, timezone or locale of the authenticated user. Omitted fields are not changed. A new email address is only used after it is confirmed with the link sent to it; an empty email removes it.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.UpdateProfile true "Profile fields"
+// @Success 200 {object} response.BaseProfileResponse "Profile updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid name, email, timezone or locale"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile [put]
+func (h *ProfileHandler) Update(c *gin.Context) {
+	var req request.UpdateProfile
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	user, pendingEmail, err := h.profile.UpdateProfile(userClaims.UserID, domain.ProfileUpdate{
+		Name:     req.Name,
+		Email:    req.Email,
+		Timezone: req.Timezone,
+		Locale:   req.Locale,
+	})
+
+	if err != nil {
+		switch err.Error() {
+		case "name is required", "invalid email address", "invalid timezone", "invalid locale":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   err.Error(),
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to update profile: ", zap.Error(err))
+		return
+	}
+
+	resp := response.BaseProfileResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data: response.ProfileResponse{
+			User:         *toUserResponse(user),
+			PendingEmail: pendingEmail,
+		},
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// VerifyEmail godoc
+// @Summary Confirm an email address
+// @Description Confirm a new email address with the single-use token sent to it. The address becomes the email of the account.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmail true "Verification token"
+// @Success 200 {object} response.MessageResponse "Email verified"
+// @Failure 400 {object} response.ErrorResponse "Invalid or expired verification token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /auth/email/verify [post]
+func (h *ProfileHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmail
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	if err := h.profile.VerifyEmail(req.Token); err != nil {
+		if err.Error() == "invalid or expired verification token" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Invalid or expired verification token",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to verify email: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Email verified",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UploadAvatar godoc
+// @Summary Upload avatar
+// @Description Upload a JPEG, PNG or GIF image (max 5 MB) as multipart field "avatar". The image is cropped to a square and stored as PNG in 32, 64, 128 and 256 pixels.
+// @Tags profile
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param avatar formData file true "Avatar image"
+// @Success 200 {object} response.BaseUserResponse "Avatar updated"
+// @Failure 400 {object} response.ErrorResponse "Missing, too large or invalid image"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/avatar [put]
+func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	// sedikit kelonggaran untuk header multipart
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, domain.MaxAvatarBytes+64<<10)
+
+	image, err := readAvatar(c)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	user, err := h.profile.SetAvatar(userClaims.UserID, image)
+
+	if err != nil {
+		if err.Error() == "unsupported or invalid image" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Unsupported or invalid image, use JPEG, PNG or GIF",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to upload avatar: ", zap.Error(err))
+		return
+	}
+
+	resp := response.BaseUserResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    *toUserResponse(user),
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func readAvatar(c *gin.Context) ([]byte, error) {
+	tooLarge := fmt.Errorf("avatar must be at most %d MB", domain.MaxAvatarBytes>>20)
+
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		// body yang melewati MaxBytesReader gagal di-parse sebelum field-nya terbaca
+		var maxBytes *http.MaxBytesError
+		if errors.As(err, &maxBytes) {
+			return nil, tooLarge
+		}
+
+		return nil, fmt.Errorf("avatar file is required")
+	}
+
+	if file.Size > domain.MaxAvatarBytes {
+		return nil, tooLarge
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(io.LimitReader(f, domain.MaxAvatarBytes))
+}
+
+// DeleteAvatar godoc
+// @Summary Remove avatar
+// @Description Remove the avatar of the authenticated user
+// @Tags profile
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.BaseUserResponse "Avatar removed"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/avatar [delete]
+func (h *ProfileHandler) DeleteAvatar(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	user, err := h.profile.DeleteAvatar(userClaims.UserID)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to delete avatar: ", zap.Error(err))
+		return
+	}
+
+	resp := response.BaseUserResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    *toUserResponse(user),
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Avatar godoc
+// @Summary Get avatar
+// @Description Get the avatar image of a user as PNG. Public, so it can be used directly in an img tag; use the avatar_url of the profile to get a cache-busting URL.
+// @Tags profile
+// @Produce png
+// @Param id path int true "User ID"
+// @Param size query int false "Size in pixels: 32, 64, 128 or 256 (default 128)"
+// @Success 200 {file} binary "Avatar image"
+// @Failure 400 {object} response.ErrorResponse "Invalid user ID or size"
+// @Failure 404 {object} response.ErrorResponse "Avatar not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /users/{id}/avatar [get]
+func (h *ProfileHandler) Avatar(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	size := domain.DefaultAvatarSize
+	if raw := c.Query("size"); raw != "" {
+		// angka yang tidak valid menjadi 0 dan ditolak service
+		size, _ = strconv.Atoi(raw)
+	}
+
+	avatar, err := h.profile.Avatar(id, size)
+
+	if err != nil {
+		switch err.Error() {
+		case "invalid avatar size":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Invalid avatar size, use 32, 64, 128 or 256",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		case "avatar not found", "user not found":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusNotFound,
+				Error:   "Avatar not found",
+			}
+
+			c.JSON(http.StatusNotFound, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to get avatar: ", zap.Error(err))
+		return
+	}
+
+	etag := fmt.Sprintf(`"%d-%d"`, avatar.UpdatedAt.Unix(), size)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=86400")
+
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, avatar.ContentType, avatar.Data)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/imaging"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// resizingProfile menjalankan resizer asli dan mencatat ukuran avatar yang dihasilkan.
+type resizingProfile struct {
+	services.ProfileService
+	calls   int
+	resized map[int][]byte
+}
+
+func (p *resizingProfile) SetAvatar(userID uint, data []byte) (*domain.User, error) {
+	p.calls++
+
+	resized, err := imaging.NewResizer().ResizeSquare(data, domain.AvatarSizes)
+	if err != nil {
+		return nil, err
+	}
+
+	p.resized = resized
+	now := time.Now()
+
+	return &domain.User{ID: userID, Username: "alice", AvatarUpdatedAt: &now}, nil
+}
+
+func avatarRequest(t *testing.T, field string, data []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile(field, "avatar.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := part.Write(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/profile/avatar", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	return req
+}
+
+func uploadAvatar(profile services.ProfileService, req *http.Request) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.PUT("/profile/avatar", func(c *gin.Context) {
+		c.Set("user", &domain.JWTClaims{UserID: 1})
+	}, NewProfileHandler(profile).UploadAvatar)
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestUploadAvatarResizes(t *testing.T) {
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 400, 300))); err != nil {
+		t.Fatal(err)
+	}
+
+	profile := &resizingProfile{}
+	rec := uploadAvatar(profile, avatarRequest(t, "avatar", img.Bytes()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+
+	for _, size := range domain.AvatarSizes {
+		cfg, err := png.DecodeConfig(bytes.NewReader(profile.resized[size]))
+		if err != nil || cfg.Width != size || cfg.Height != size {
+			t.Errorf("size %d = %dx%d, %v", size, cfg.Width, cfg.Height, err)
+		}
+	}
+}
+
+func TestUploadAvatarRejectsInvalidUploads(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		data  []byte
+		want  string
+	}{
+		{"non-image", "avatar", []byte("#!/bin/sh\necho not an image\n"), "Unsupported or invalid image"},
+		{"just over the limit", "avatar", make([]byte, domain.MaxAvatarBytes+1), "avatar must be at most 5 MB"},
+		{"far over the limit", "avatar", make([]byte, 2*domain.MaxAvatarBytes), "avatar must be at most 5 MB"},
+		{"wrong field", "file", []byte("x"), "avatar file is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &resizingProfile{}
+			rec := uploadAvatar(profile, avatarRequest(t, tt.field, tt.data))
+
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+				t.Fatalf("status = %d, body = %q, want 400 with %q", rec.Code, rec.Body.String(), tt.want)
+			}
+
+			// file yang terlalu besar ditolak sebelum sampai ke service
+			if strings.Contains(tt.name, "limit") && profile.calls != 0 {
+				t.Errorf("SetAvatar called for an oversized upload")
+			}
+		})
+	}
+}
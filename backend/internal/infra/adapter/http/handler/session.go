@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SessionHandler struct {
+	sessions services.SessionService
+}
+
+func NewSessionHandler(sessions services.SessionService) *SessionHandler {
+	return &SessionHandler{sessions: sessions}
+}
+
+// List godoc
+// @Summary List active sessions
+// @Description List the active logins of the authenticated user with device, IP and last activity. The session of the current token is marked with current.
+// @Tags sessions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.ListSessionResponse "Active sessions"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/sessions [get]
+func (h *SessionHandler) List(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	sessions, err := h.sessions.List(userClaims.UserID)
+
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to list sessions: ", zap.Error(err))
+		return
+	}
+
+	data := make([]response.Session, 0, len(sessions))
+	for _, session := range sessions {
+		data = append(data, response.Session{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == userClaims.ID,
+		})
+	}
+
+	resp := response.ListSessionResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    data,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Revoke godoc
+// @Summary Revoke a session
+// @Description Log out a session, for example on a lost device. Tokens of that session are rejected immediately.
+// @Tags sessions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.MessageResponse "Session revoked"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Session not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/sessions/{id} [delete]
+func (h *SessionHandler) Revoke(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.sessions.Revoke(userClaims.UserID, c.Param("id")); err != nil {
+		if err.Error() == "session not found" {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusNotFound,
+				Error:   "Session not found",
+			}
+
+			c.JSON(http.StatusNotFound, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to revoke session: ", zap.Error(err))
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Session revoked",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
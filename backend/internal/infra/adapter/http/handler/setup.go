@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// bootstrapTokenHeader membawa token dari konfigurasi bootstrap.token.
+const bootstrapTokenHeader = "X-Bootstrap-Token"
+
+type SetupHandler struct {
+	bootstrap services.BootstrapService
+}
+
+func NewSetupHandler(bootstrap services.BootstrapService) *SetupHandler {
+	return &SetupHandler{bootstrap: bootstrap}
+}
+
+// CreateAdmin godoc
+// @Summary Create the first admin
+// @Description One-time setup of the first admin account. Requires the bootstrap token from the server configuration in the X-Bootstrap-Token header and only works while no admin exists.
+// @Tags setup
+// @Accept json
+// @Produce json
+// @Param X-Bootstrap-Token header string true "Bootstrap token"
+// @Param request body request.SetupAdmin true "Admin account"
+// @Success 201 {object} response.BaseUserResponse "Admin created"
+// @Failure 400 {object} response.ErrorResponse "Invalid input, reserved username or password does not meet the policy"
+// @Failure 401 {object} response.ErrorResponse "Invalid bootstrap token"
+// @Failure 404 {object} response.ErrorResponse "Setup is disabled"
+// @Failure 409 {object} response.ErrorResponse "Setup already completed or username already exists"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /setup/admin [post]
+func (h *SetupHandler) CreateAdmin(c *gin.Context) {
+	var req request.SetupAdmin
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	user, err := h.bootstrap.CreateAdmin(c.GetHeader(bootstrapTokenHeader), domain.AdminSetup{
+		Name:     req.Name,
+		Username: req.Username,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		var policyErr *domain.PasswordPolicyError
+		if errors.As(err, &policyErr) {
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   policyErr.Error(),
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		}
+
+		switch err.Error() {
+		case "setup is disabled":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusNotFound,
+				Error:   "Setup is disabled",
+			}
+
+			c.JSON(http.StatusNotFound, resp)
+			return
+		case "invalid bootstrap token":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusUnauthorized,
+				Error:   "Invalid bootstrap token",
+			}
+
+			c.JSON(http.StatusUnauthorized, resp)
+			return
+		case "setup has already been completed":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusConflict,
+				Error:   "Setup has already been completed",
+			}
+
+			c.JSON(http.StatusConflict, resp)
+			return
+		case "username is reserved":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusBadRequest,
+				Error:   "Usernames starting with \"" + domain.DeletedUsernamePrefix + "\" are reserved",
+			}
+
+			c.JSON(http.StatusBadRequest, resp)
+			return
+		case "username is already exists":
+			resp := response.ErrorResponse{
+				Success: false,
+				Code:    http.StatusConflict,
+				Error:   "Username already exists",
+			}
+
+			c.JSON(http.StatusConflict, resp)
+			return
+		}
+
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusInternalServerError,
+			Error:   "Internal server error",
+		}
+
+		c.JSON(http.StatusInternalServerError, resp)
+
+		logger.Error("failed to create initial admin: ", zap.Error(err))
+		return
+	}
+
+	resp := response.BaseUserResponse{
+		Success: true,
+		Code:    http.StatusCreated,
+		Data: response.UserResponse{
+			ID:       user.ID,
+			Name:     user.Name,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     string(user.Role),
+		},
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
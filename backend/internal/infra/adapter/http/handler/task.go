@@ -86,14 +86,7 @@ func (h *TaskHandler) Create(c *gin.Context) {
 	resp := response.BaseTaskResponse{
 		Success: true,
 		Code:    http.StatusCreated,
-		Data: response.Task{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      string(task.Status),
-			Deadline:    task.Deadline,
-			CreatedAt:   task.CreatedAt,
-		},
+		Data:    toTaskResponse(&task, middleware.GetLocation(c)),
 	}
 
 	c.JSON(http.StatusCreated, resp)
@@ -107,9 +100,10 @@ func (h *TaskHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param status query string false "Filter by task status" Enums(pending, in_progress, completed)
-// @Param deadline query string false "Filter by deadline date (YYYY-MM-DD format)" Format(date)
+// @Param deadline query string false "Tasks due on or before this date in the user's time zone (YYYY-MM-DD format)" Format(date)
+// @Param X-Timezone header string false "IANA time zone overriding the profile time zone, e.g. Asia/Jakarta"
 // @Success 200 {object} response.ListTaskResponse "Successfully retrieved tasks"
-// @Failure 400 {object} response.ErrorResponse "Invalid deadline format"
+// @Failure 400 {object} response.ErrorResponse "Invalid deadline format or time zone"
 // @Failure 401 {object} response.ErrorResponse "Unauthorized - invalid or missing token"
 // @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /tasks [get]
@@ -128,27 +122,16 @@ func (h *TaskHandler) Get(c *gin.Context) {
 		return
 	}
 
-	var status *domain.TaskStatus
-	if s := c.Query("status"); s != "" {
-		ts := domain.TaskStatus(s)
-		status = &ts
-	}
-
-	var deadline *time.Time
-
-	if d := c.Query("deadline"); d != "" {
-		parsedDeadline, err := time.Parse("2006-01-02", d)
-		if err != nil {
-			resp := response.ErrorResponse{
-				Success: false,
-				Code:    http.StatusBadRequest,
-				Error:   "Invalid deadline format. Use YYYY-MM-DD.",
-			}
-
-			c.JSON(http.StatusBadRequest, resp)
-			return
+	status, deadline, err := parseTaskFilters(c)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid deadline format. Use YYYY-MM-DD.",
 		}
-		deadline = &parsedDeadline
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
 	}
 
 	tasks, err := h.taskService.GetTasks(userClaims.UserID, status, deadline)
@@ -168,15 +151,9 @@ func (h *TaskHandler) Get(c *gin.Context) {
 
 	var resp []response.Task
 
+	loc := middleware.GetLocation(c)
 	for _, task := range tasks {
-		resp = append(resp, response.Task{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      string(task.Status),
-			Deadline:    task.Deadline,
-			CreatedAt:   task.CreatedAt,
-		})
+		resp = append(resp, toTaskResponse(&task, loc))
 	}
 
 	response := response.ListTaskResponse{
@@ -283,14 +260,7 @@ func (h *TaskHandler) GetByID(c *gin.Context) {
 	response := response.BaseTaskResponse{
 		Success: true,
 		Code:    http.StatusOK,
-		Data: response.Task{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      string(task.Status),
-			Deadline:    task.Deadline,
-			CreatedAt:   task.CreatedAt,
-		},
+		Data:    toTaskResponse(task, middleware.GetLocation(c)),
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -399,14 +369,7 @@ func (h *TaskHandler) Update(c *gin.Context) {
 	resp := response.BaseTaskResponse{
 		Success: true,
 		Code:    http.StatusOK,
-		Data: response.Task{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      string(task.Status),
-			Deadline:    task.Deadline,
-			CreatedAt:   task.CreatedAt,
-		},
+		Data:    toTaskResponse(&task, middleware.GetLocation(c)),
 	}
 
 	c.JSON(http.StatusOK, resp)
@@ -498,6 +461,29 @@ func (h *TaskHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// parseTaskFilters membaca filter status dan deadline (YYYY-MM-DD) dari query string.
+// Tanggal deadline dibaca dalam zona waktu user dan mencakup seluruh hari tersebut,
+// jadi yang dikembalikan adalah awal hari berikutnya (batas eksklusif).
+func parseTaskFilters(c *gin.Context) (*domain.TaskStatus, *time.Time, error) {
+	var status *domain.TaskStatus
+	if s := c.Query("status"); s != "" {
+		ts := domain.TaskStatus(s)
+		status = &ts
+	}
+
+	var deadline *time.Time
+	if d := c.Query("deadline"); d != "" {
+		parsedDeadline, err := time.ParseInLocation("2006-01-02", d, middleware.GetLocation(c))
+		if err != nil {
+			return nil, nil, err
+		}
+		dueBefore := parsedDeadline.AddDate(0, 0, 1)
+		deadline = &dueBefore
+	}
+
+	return status, deadline, nil
+}
+
 func derefString(s *string) string {
 	if s != nil {
 		return *s
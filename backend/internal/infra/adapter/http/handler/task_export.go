@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// taskExporter menulis task satu per satu ke response tanpa menampung seluruh hasil.
+type taskExporter interface {
+	begin() error
+	write(task *domain.Task) error
+	end() error
+}
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"json":   "application/json; charset=utf-8",
+	"ndjson": "application/x-ndjson; charset=utf-8",
+}
+
+// Export godoc
+// @Summary Export tasks
+// @Description Export the tasks of the authenticated user as CSV, JSON or NDJSON. Accepts the same filters as GET /tasks. Rows are streamed as they are read from the database.
+// @Tags tasks
+// @Produce text/csv
+// @Produce json
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param format query string false "Export format" Enums(csv, json, ndjson) default(csv)
+// @Param status query string false "Filter by task status"
+// @Param deadline query string false "Tasks due on or before this date in the user's time zone (YYYY-MM-DD format)" Format(date)
+// @Param X-Timezone header string false "IANA time zone overriding the profile time zone, e.g. Asia/Jakarta"
+// @Success 200 {file} file "Exported tasks"
+// @Failure 400 {object} response.ErrorResponse "Invalid format, deadline or time zone"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - invalid or missing token"
+// @Router /tasks/export [get]
+func (h *TaskHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+
+	contentType, supported := exportContentTypes[format]
+	if !supported {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid format. Use csv, json or ndjson.",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	status, deadline, err := parseTaskFilters(c)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   "Invalid deadline format. Use YYYY-MM-DD.",
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	filename := fmt.Sprintf("tasks-%s.%s", time.Now().Format("20060102-150405"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Status(http.StatusOK)
+
+	exporter := newTaskExporter(format, c.Writer, middleware.GetLocation(c))
+
+	// header sudah terkirim, error di tengah stream hanya bisa dicatat
+	err = exporter.begin()
+	if err == nil {
+		err = h.taskService.ExportTasks(userClaims.UserID, status, deadline, func(task *domain.Task) error {
+			if err := exporter.write(task); err != nil {
+				return err
+			}
+
+			c.Writer.Flush()
+			return nil
+		})
+	}
+
+	if err == nil {
+		err = exporter.end()
+	}
+
+	if err != nil {
+		logger.Error("failed to export tasks: ", zap.Error(err))
+		_ = c.Error(err)
+	}
+}
+
+func newTaskExporter(format string, w io.Writer, loc *time.Location) taskExporter {
+	switch format {
+	case "json":
+		return &jsonTaskExporter{w: w, enc: json.NewEncoder(w), loc: loc}
+	case "ndjson":
+		return &ndjsonTaskExporter{enc: json.NewEncoder(w), loc: loc}
+	default:
+		return &csvTaskExporter{w: csv.NewWriter(w), loc: loc}
+	}
+}
+
+// toTaskResponse menulis waktu task dalam zona waktu request, sehingga JSON berisi RFC 3339 dengan offset user.
+func toTaskResponse(task *domain.Task, loc *time.Location) response.Task {
+	return response.Task{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      string(task.Status),
+		Deadline:    inLocation(task.Deadline, loc),
+		Labels:      task.Labels,
+		CreatedAt:   task.CreatedAt.In(loc),
+	}
+}
+
+func inLocation(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	local := t.In(loc)
+	return &local
+}
+
+type csvTaskExporter struct {
+	w   *csv.Writer
+	loc *time.Location
+}
+
+func (e *csvTaskExporter) begin() error {
+	return e.w.Write([]string{"id", "title", "description", "status", "deadline", "created_at"})
+}
+
+func (e *csvTaskExporter) write(task *domain.Task) error {
+	deadline := ""
+	if task.Deadline != nil {
+		deadline = task.Deadline.In(e.loc).Format(time.RFC3339)
+	}
+
+	err := e.w.Write([]string{
+		strconv.FormatUint(uint64(task.ID), 10),
+		task.Title,
+		task.Description,
+		string(task.Status),
+		deadline,
+		task.CreatedAt.In(e.loc).Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvTaskExporter) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type jsonTaskExporter struct {
+	w     io.Writer
+	enc   *json.Encoder
+	loc   *time.Location
+	count int
+}
+
+func (e *jsonTaskExporter) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonTaskExporter) write(task *domain.Task) error {
+	if e.count > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.count++
+
+	return e.enc.Encode(toTaskResponse(task, e.loc))
+}
+
+func (e *jsonTaskExporter) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+type ndjsonTaskExporter struct {
+	enc *json.Encoder
+	loc *time.Location
+}
+
+func (e *ndjsonTaskExporter) begin() error {
+	return nil
+}
+
+func (e *ndjsonTaskExporter) write(task *domain.Task) error {
+	return e.enc.Encode(toTaskResponse(task, e.loc))
+}
+
+func (e *ndjsonTaskExporter) end() error {
+	return nil
+}
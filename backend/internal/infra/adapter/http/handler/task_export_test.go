@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportingTasks mengirim task ke callback satu per satu dan mencatat filter yang diterima.
+// Sebelum task berikutnya dikirim, onRow dipanggil untuk memeriksa apa yang sudah sampai ke client.
+type exportingTasks struct {
+	services.TaskService
+	tasks     []domain.Task
+	onRow     func(i int)
+	called    bool
+	userID    uint
+	status    *domain.TaskStatus
+	dueBefore *time.Time
+}
+
+func (e *exportingTasks) ExportTasks(userId uint, status *domain.TaskStatus, dueBefore *time.Time, fn func(task *domain.Task) error) error {
+	e.called, e.userID, e.status, e.dueBefore = true, userId, status, dueBefore
+
+	for i := range e.tasks {
+		if err := fn(&e.tasks[i]); err != nil {
+			return err
+		}
+
+		if e.onRow != nil {
+			e.onRow(i)
+		}
+	}
+
+	return nil
+}
+
+// profileTimezone mengembalikan user dengan zona waktu profil untuk middleware Timezone.
+type profileTimezone struct {
+	services.AuthService
+	timezone string
+}
+
+func (p profileTimezone) Me(userID uint) (*domain.User, error) {
+	return &domain.User{ID: userID, Timezone: p.timezone}, nil
+}
+
+func exportEngine(tasks services.TaskService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.GET("/tasks/export", func(c *gin.Context) {
+		c.Set("user", &domain.JWTClaims{UserID: 7})
+	}, middleware.Timezone(profileTimezone{timezone: "America/New_York"}), NewTaskHandler(tasks).Export)
+
+	return engine
+}
+
+func exportRequest(query, timezone string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/tasks/export"+query, nil)
+	if timezone != "" {
+		req.Header.Set(middleware.TimezoneHeader, timezone)
+	}
+
+	return req
+}
+
+func exportFixture() []domain.Task {
+	deadline := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 2, 1, 20, 30, 0, 0, time.UTC)
+
+	return []domain.Task{
+		{ID: 1, Title: "Write report", Description: "quarterly, with \"quotes\"", Status: domain.ToDo, Deadline: &deadline, CreatedAt: created},
+		{ID: 2, Title: "Review", Description: "no deadline", Status: domain.Done, CreatedAt: created},
+	}
+}
+
+func TestExportCSVStreamsRows(t *testing.T) {
+	rec := httptest.NewRecorder()
+	tasks := &exportingTasks{tasks: exportFixture()}
+
+	// baris pertama harus sudah di-flush ke client sebelum task kedua dibaca
+	tasks.onRow = func(i int) {
+		if i == 0 && (!rec.Flushed || !strings.Contains(rec.Body.String(), "Write report")) {
+			t.Errorf("first row not flushed before the next task, body = %q", rec.Body.String())
+		}
+	}
+
+	exportEngine(tasks).ServeHTTP(rec, exportRequest("", "Asia/Jakarta"))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Fatalf("status = %d, content type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	if disposition := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, `attachment; filename="tasks-`) || !strings.HasSuffix(disposition, `.csv"`) {
+		t.Errorf("Content-Disposition = %q", disposition)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+
+	want := [][]string{
+		{"id", "title", "description", "status", "deadline", "created_at"},
+		{"1", "Write report", "quarterly, with \"quotes\"", "To Do", "2026-03-01T17:00:00+07:00", "2026-02-02T03:30:00+07:00"},
+		{"2", "Review", "no deadline", "Done", "", "2026-02-02T03:30:00+07:00"},
+	}
+
+	if len(records) != len(want) {
+		t.Fatalf("records = %q, want %q", records, want)
+	}
+
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
+
+func TestExportJSONStreamsArray(t *testing.T) {
+	rec := httptest.NewRecorder()
+	tasks := &exportingTasks{tasks: exportFixture()}
+
+	tasks.onRow = func(i int) {
+		if i == 0 && (!rec.Flushed || !strings.HasPrefix(rec.Body.String(), `[{"id":1`)) {
+			t.Errorf("first task not flushed before the next task, body = %q", rec.Body.String())
+		}
+	}
+
+	exportEngine(tasks).ServeHTTP(rec, exportRequest("?format=json", "Asia/Jakarta"))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Fatalf("status = %d, content type = %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	var exported []response.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &exported); err != nil {
+		t.Fatalf("body is not a JSON array: %v, %q", err, rec.Body.String())
+	}
+
+	if len(exported) != 2 || exported[0].Title != "Write report" || exported[1].Deadline != nil {
+		t.Fatalf("exported = %+v", exported)
+	}
+
+	// waktu ditulis dengan offset zona waktu request
+	if !strings.Contains(rec.Body.String(), `"deadline":"2026-03-01T17:00:00+07:00"`) {
+		t.Errorf("body = %q, want the deadline in +07:00", rec.Body.String())
+	}
+}
+
+func TestExportJSONWithoutTasks(t *testing.T) {
+	rec := httptest.NewRecorder()
+	exportEngine(&exportingTasks{}).ServeHTTP(rec, exportRequest("?format=json", ""))
+
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Fatalf("status = %d, body = %q, want an empty array", rec.Code, rec.Body.String())
+	}
+}
+
+func TestExportNDJSONWritesOneTaskPerLine(t *testing.T) {
+	rec := httptest.NewRecorder()
+	exportEngine(&exportingTasks{tasks: exportFixture()}).ServeHTTP(rec, exportRequest("?format=ndjson", ""))
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q, want 2", lines)
+	}
+
+	for _, line := range lines {
+		var task response.Task
+		if err := json.Unmarshal([]byte(line), &task); err != nil {
+			t.Errorf("line %q: %v", line, err)
+		}
+	}
+
+	// tanpa header X-Timezone dipakai zona waktu profil user
+	if !strings.Contains(lines[0], `"deadline":"2026-03-01T05:00:00-05:00"`) {
+		t.Errorf("line = %q, want the deadline in the profile time zone", lines[0])
+	}
+}
+
+func TestExportPassesFilters(t *testing.T) {
+	tasks := &exportingTasks{}
+	rec := httptest.NewRecorder()
+	exportEngine(tasks).ServeHTTP(rec, exportRequest("?status=In+Progress&deadline=2026-03-01", "Asia/Jakarta"))
+
+	if rec.Code != http.StatusOK || !tasks.called {
+		t.Fatalf("status = %d, called = %v", rec.Code, tasks.called)
+	}
+
+	if tasks.userID != 7 {
+		t.Errorf("user = %d, want 7", tasks.userID)
+	}
+
+	if tasks.status == nil || *tasks.status != domain.InProgress {
+		t.Errorf("status = %v, want In Progress", tasks.status)
+	}
+
+	// seluruh 1 Maret di Jakarta, batas eksklusif 2 Maret 00:00 WIB
+	want := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	if tasks.dueBefore == nil || !tasks.dueBefore.Equal(want) {
+		t.Errorf("dueBefore = %v, want %v", tasks.dueBefore, want)
+	}
+}
+
+func TestExportRejectsInvalidParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		timezone string
+		want     string
+	}{
+		{"unknown format", "?format=xml", "", "Invalid format"},
+		{"bad deadline", "?deadline=01-03-2026", "", "Invalid deadline format"},
+		{"bad time zone", "", "Mars/Olympus", "invalid X-Timezone header"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &exportingTasks{tasks: exportFixture()}
+			rec := httptest.NewRecorder()
+			exportEngine(tasks).ServeHTTP(rec, exportRequest(tt.query, tt.timezone))
+
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), tt.want) {
+				t.Fatalf("status = %d, body = %q, want 400 with %q", rec.Code, rec.Body.String(), tt.want)
+			}
+
+			if tasks.called {
+				t.Error("tasks were exported for an invalid request")
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go.uber.org/zap"
+)
+
+const maxImportSize = 10 << 20
+
+// importRow adalah satu baris mentah dari file import, sebelum divalidasi.
+type importRow struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	Deadline    string `json:"deadline"`
+
+	decodeErr error
+}
+
+// Import godoc
+// @Summary Import tasks
+// @Description Import tasks from a CSV or JSON file. The file can be sent as multipart field "file" or as the raw request body.
+// @Description CSV needs a header row with the columns title, description, status and deadline. JSON must be an array of objects with the same keys.
+// @Description Every row is validated with the same rules as task creation. Rows are numbered from 1, not counting the CSV header.
+// @Tags tasks
+// @Accept mpfd
+// @Accept text/csv
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file false "CSV or JSON file"
+// @Param format query string false "Input format, detected from the file name or Content-Type when omitted" Enums(csv, json)
+// @Param dry_run query bool false "Validate only, do not insert anything"
+// @Success 200 {object} response.BaseImportResponse "Import report"
+// @Failure 400 {object} response.ErrorResponse "Unreadable file or unknown format"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized - invalid or missing token"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /tasks/import [post]
+func (h *TaskHandler) Import(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	rows, err := readImportRows(c)
+	if err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	result := response.ImportResult{
+		DryRun: c.Query("dry_run") == "true",
+		Total:  len(rows),
+		Errors: []response.ImportRowError{},
+	}
+
+	var tasks []domain.Task
+	var taskRows []int
+
+	loc := middleware.GetLocation(c)
+	for i, row := range rows {
+		task, errs := validateImportRow(row, loc)
+		if len(errs) > 0 {
+			result.Errors = append(result.Errors, response.ImportRowError{Row: i + 1, Errors: errs})
+			continue
+		}
+
+		tasks = append(tasks, task)
+		taskRows = append(taskRows, i+1)
+	}
+
+	result.Valid = len(tasks)
+
+	if !result.DryRun && len(tasks) > 0 {
+		imported, err := h.taskService.ImportTasks(userClaims.UserID, tasks)
+		result.Imported = imported
+
+		if err != nil {
+			logger.Error("failed to import tasks: ", zap.Error(err))
+
+			for _, row := range taskRows[imported:] {
+				result.Errors = append(result.Errors, response.ImportRowError{Row: row, Errors: []string{"not imported: database error"}})
+			}
+		}
+	}
+
+	result.Failed = len(result.Errors)
+
+	resp := response.BaseImportResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    result,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func readImportRows(c *gin.Context) ([]importRow, error) {
+	format := c.Query("format")
+
+	var body io.Reader = c.Request.Body
+
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		body = f
+
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+		}
+	} else if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+
+		switch mediaType {
+		case "text/csv":
+			format = "csv"
+		case "application/json":
+			format = "json"
+		}
+	}
+
+	switch format {
+	case "csv":
+		return readCSVRows(body)
+	case "json":
+		return readJSONRows(body)
+	default:
+		return nil, errors.New("unknown import format, use csv or json")
+	}
+}
+
+func readCSVRows(r io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("CSV header must contain a title column")
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []importRow
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		rows = append(rows, importRow{
+			Title:       field(record, "title"),
+			Description: field(record, "description"),
+			Status:      field(record, "status"),
+			Deadline:    field(record, "deadline"),
+		})
+	}
+
+	return rows, nil
+}
+
+func readJSONRows(r io.Reader) ([]importRow, error) {
+	var raw []json.RawMessage
+
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to read JSON, expected an array of tasks: %w", err)
+	}
+
+	rows := make([]importRow, len(raw))
+
+	for i, item := range raw {
+		if err := json.Unmarshal(item, &rows[i]); err != nil {
+			rows[i] = importRow{decodeErr: err}
+		}
+	}
+
+	return rows, nil
+}
+
+// validateImportRow memakai aturan validasi yang sama dengan request.CreateTask.
+func validateImportRow(row importRow, loc *time.Location) (domain.Task, []string) {
+	if row.decodeErr != nil {
+		return domain.Task{}, []string{"invalid task object: " + row.decodeErr.Error()}
+	}
+
+	req := request.CreateTask{
+		Title:       row.Title,
+		Description: row.Description,
+		Status:      domain.TaskStatus(row.Status),
+	}
+
+	var errs []string
+
+	if row.Deadline != "" {
+		deadline, err := parseImportDeadline(row.Deadline, loc)
+		if err != nil {
+			errs = append(errs, "deadline must be RFC 3339 or YYYY-MM-DD")
+		} else {
+			req.Deadline = &deadline
+		}
+	}
+
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		errs = append(errs, validationMessages(err)...)
+	}
+
+	if len(errs) > 0 {
+		return domain.Task{}, errs
+	}
+
+	return domain.Task{
+		Title:       req.Title,
+		Description: req.Description,
+		Status:      req.Status,
+		Deadline:    req.Deadline,
+	}, nil
+}
+
+// parseImportDeadline membaca tanggal tanpa jam sebagai tengah malam di zona waktu user.
+func parseImportDeadline(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.ParseInLocation("2006-01-02", value, loc)
+}
+
+func validationMessages(err error) []string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []string{err.Error()}
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+
+	for _, fe := range validationErrors {
+		field := strings.ToLower(fe.Field())
+
+		switch fe.Tag() {
+		case "required":
+			messages = append(messages, field+" is required")
+		case "max":
+			messages = append(messages, fmt.Sprintf("%s must be at most %s characters", field, fe.Param()))
+		case "oneof":
+			messages = append(messages, fmt.Sprintf("%s must be one of %s", field, fe.Param()))
+		default:
+			messages = append(messages, fmt.Sprintf("%s failed on %s validation", field, fe.Tag()))
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateImportRow(t *testing.T) {
+	tests := []struct {
+		name string
+		row  importRow
+		want string
+	}{
+		{"valid", importRow{Title: "Task", Description: "d", Status: "To Do", Deadline: "2026-10-20"}, ""},
+		{"title at the limit", importRow{Title: strings.Repeat("é", 255), Description: "d", Status: "Done"}, ""},
+		{"title too long", importRow{Title: strings.Repeat("a", 256), Description: "d", Status: "Done"}, "title must be at most 255 characters"},
+		{"missing title", importRow{Description: "d", Status: "Done"}, "title is required"},
+		{"unknown status", importRow{Title: "Task", Description: "d", Status: "Later"}, "status must be one of"},
+		{"bad deadline", importRow{Title: "Task", Description: "d", Status: "Done", Deadline: "20/10/2026"}, "deadline must be RFC 3339 or YYYY-MM-DD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, errs := validateImportRow(tt.row, time.UTC)
+
+			if tt.want == "" {
+				if len(errs) != 0 || task.Title != tt.row.Title {
+					t.Fatalf("validateImportRow = %+v, %v, want valid task", task, errs)
+				}
+				return
+			}
+
+			if len(errs) == 0 || !strings.Contains(strings.Join(errs, "; "), tt.want) {
+				t.Fatalf("validateImportRow errors = %v, want %q", errs, tt.want)
+			}
+		})
+	}
+}
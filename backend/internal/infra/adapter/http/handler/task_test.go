@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/http/middleware"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func filterContext(t *testing.T, query, timezone string) *gin.Context {
+	t.Helper()
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/tasks"+query, nil)
+	c.Set("location", loc)
+
+	return c
+}
+
+func TestParseTaskFiltersDayBoundary(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		date     string
+		want     time.Time
+	}{
+		{"Jakarta", "Asia/Jakarta", "2026-03-01", time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)},
+		{"half hour offset", "Asia/Kolkata", "2026-03-01", time.Date(2026, 3, 1, 18, 30, 0, 0, time.UTC)},
+		{"ahead of UTC across the date line", "Pacific/Auckland", "2026-03-01", time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)},
+		{"behind UTC", "America/New_York", "2026-03-01", time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC)},
+		// 8 Maret 2026 hanya 23 jam di New York karena DST dimulai
+		{"daylight saving start", "America/New_York", "2026-03-08", time.Date(2026, 3, 9, 4, 0, 0, 0, time.UTC)},
+		{"UTC", "UTC", "2026-03-01", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, dueBefore, err := parseTaskFilters(filterContext(t, "?deadline="+tt.date, tt.timezone))
+			if err != nil {
+				t.Fatalf("parseTaskFilters: %v", err)
+			}
+
+			if dueBefore == nil || !dueBefore.Equal(tt.want) {
+				t.Fatalf("dueBefore = %v, want %v", dueBefore, tt.want)
+			}
+
+			// batas eksklusif: detik terakhir hari itu masuk, tengah malam berikutnya tidak
+			loc, _ := time.LoadLocation(tt.timezone)
+			day, _ := time.ParseInLocation("2006-01-02", tt.date, loc)
+			lastSecond := day.AddDate(0, 0, 1).Add(-time.Second)
+			nextMidnight := day.AddDate(0, 0, 1)
+
+			if !lastSecond.Before(*dueBefore) || nextMidnight.Before(*dueBefore) {
+				t.Errorf("dueBefore %v does not end at local midnight after %s", dueBefore, tt.date)
+			}
+		})
+	}
+}
+
+func TestParseTaskFiltersStatusAndErrors(t *testing.T) {
+	status, dueBefore, err := parseTaskFilters(filterContext(t, "?status=Done", "Asia/Jakarta"))
+	if err != nil || status == nil || *status != domain.Done || dueBefore != nil {
+		t.Fatalf("parseTaskFilters = %v, %v, %v, want status Done without deadline", status, dueBefore, err)
+	}
+
+	for _, date := range []string{"01-03-2026", "2026-02-30", "2026-03-01T10:00:00Z", "tomorrow"} {
+		if _, _, err := parseTaskFilters(filterContext(t, "?deadline="+date, "Asia/Jakarta")); err == nil {
+			t.Errorf("deadline %q accepted", date)
+		}
+	}
+}
+
+// listingTasks mencatat batas deadline yang diterima service.
+type listingTasks struct {
+	services.TaskService
+	called    bool
+	dueBefore *time.Time
+}
+
+func (l *listingTasks) GetTasks(userId uint, status *domain.TaskStatus, dueBefore *time.Time) ([]domain.Task, error) {
+	l.called, l.dueBefore = true, dueBefore
+	return nil, nil
+}
+
+func TestGetTasksTimezoneHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		timezone string
+		want     int
+		due      time.Time
+	}{
+		{"header overrides profile zone", "Asia/Jakarta", http.StatusOK, time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)},
+		{"profile zone without header", "", http.StatusOK, time.Date(2026, 3, 2, 5, 0, 0, 0, time.UTC)},
+		{"unknown zone", "Mars/Olympus", http.StatusBadRequest, time.Time{}},
+		{"offset is not a zone name", "+07:00", http.StatusBadRequest, time.Time{}},
+		{"Local is rejected", "Local", http.StatusBadRequest, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &listingTasks{}
+
+			engine := gin.New()
+			engine.GET("/tasks", func(c *gin.Context) {
+				c.Set("user", &domain.JWTClaims{UserID: 7})
+			}, middleware.Timezone(profileTimezone{timezone: "America/New_York"}), NewTaskHandler(tasks).Get)
+
+			req := httptest.NewRequest(http.MethodGet, "/tasks?deadline=2026-03-01", nil)
+			if tt.timezone != "" {
+				req.Header.Set(middleware.TimezoneHeader, tt.timezone)
+			}
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, body = %q, want %d", rec.Code, rec.Body.String(), tt.want)
+			}
+
+			if tt.want == http.StatusBadRequest {
+				if tasks.called || !strings.Contains(rec.Body.String(), "invalid X-Timezone header") {
+					t.Errorf("called = %v, body = %q, want the request rejected before the service", tasks.called, rec.Body.String())
+				}
+				return
+			}
+
+			if tasks.dueBefore == nil || !tasks.dueBefore.Equal(tt.due) {
+				t.Errorf("dueBefore = %v, want %v", tasks.dueBefore, tt.due)
+			}
+		})
+	}
+}
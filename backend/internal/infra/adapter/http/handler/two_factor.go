@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"net/http"
+	"task-management/internal/applications/dto/request"
+	"task-management/internal/applications/dto/response"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/infra/adapter/http/middleware"
+	"task-management/internal/infra/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TwoFactorHandler struct {
+	twoFactor services.TwoFactorService
+}
+
+func NewTwoFactorHandler(twoFactor services.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactor: twoFactor}
+}
+
+// Enroll godoc
+// @Summary Start 2FA enrollment
+// @Description Generate a new TOTP secret and otpauth URI to scan with an authenticator app. 2FA is not active until it is confirmed with /profile/2fa/enable.
+// @Tags 2fa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.BaseTwoFactorEnrollmentResponse "Secret and otpauth URI"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "2FA is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/2fa/enroll [post]
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	enrollment, err := h.twoFactor.Enroll(userClaims.UserID)
+
+	if err != nil {
+		h.writeError(c, err, "failed to enroll 2fa: ")
+		return
+	}
+
+	resp := response.BaseTwoFactorEnrollmentResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data: response.TwoFactorEnrollment{
+			Secret:     enrollment.Secret,
+			OTPAuthURI: enrollment.URI,
+		},
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Enable godoc
+// @Summary Enable 2FA
+// @Description Confirm enrollment with a code from the authenticator app. Returns one-time recovery codes that are only shown once.
+// @Tags 2fa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.TwoFactorCode true "TOTP code"
+// @Success 200 {object} response.BaseRecoveryCodesResponse "Recovery codes"
+// @Failure 400 {object} response.ErrorResponse "Invalid code or enrollment not started"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 409 {object} response.ErrorResponse "2FA is already enabled"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/2fa/enable [post]
+func (h *TwoFactorHandler) Enable(c *gin.Context) {
+	var req request.TwoFactorCode
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	codes, err := h.twoFactor.Enable(userClaims.UserID, req.Code)
+
+	if err != nil {
+		h.writeError(c, err, "failed to enable 2fa: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, toRecoveryCodesResponse(codes))
+}
+
+// Disable godoc
+// @Summary Disable 2FA
+// @Description Turn off 2FA. Requires a TOTP or recovery code and the current password, accounts that only use SSO send no password. Remaining recovery codes are deleted.
+// @Tags 2fa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.DisableTwoFactor true "Password and code"
+// @Success 200 {object} response.MessageResponse "2FA disabled"
+// @Failure 400 {object} response.ErrorResponse "Invalid password or code, or 2FA is not enabled"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	var req request.DisableTwoFactor
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	if err := h.twoFactor.Disable(userClaims.UserID, req.Password, req.Code); err != nil {
+		h.writeError(c, err, "failed to disable 2fa: ")
+		return
+	}
+
+	resp := response.MessageResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    "Two-factor authentication disabled",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes with a new set. Requires a TOTP or recovery code.
+// @Tags 2fa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body request.TwoFactorCode true "TOTP or recovery code"
+// @Success 200 {object} response.BaseRecoveryCodesResponse "Recovery codes"
+// @Failure 400 {object} response.ErrorResponse "Invalid code or 2FA is not enabled"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /profile/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req request.TwoFactorCode
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusBadRequest,
+			Error:   err.Error(),
+		}
+
+		c.JSON(http.StatusBadRequest, resp)
+		return
+	}
+
+	// claims token dari middleware
+	userClaims, ok := middleware.GetUserClaims(c)
+
+	if !ok {
+		resp := response.ErrorResponse{
+			Success: false,
+			Code:    http.StatusUnauthorized,
+			Error:   "Unauthorized",
+		}
+
+		c.JSON(http.StatusUnauthorized, resp)
+		return
+	}
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(userClaims.UserID, req.Code)
+
+	if err != nil {
+		h.writeError(c, err, "failed to regenerate recovery codes: ")
+		return
+	}
+
+	c.JSON(http.StatusOK, toRecoveryCodesResponse(codes))
+}
+
+func (h *TwoFactorHandler) writeError(c *gin.Context, err error, logMessage string) {
+	status := http.StatusInternalServerError
+	message := "Internal server error"
+
+	switch err.Error() {
+	case "two-factor authentication is already enabled":
+		status, message = http.StatusConflict, "Two-factor authentication is already enabled"
+	case "two-factor authentication is not enabled":
+		status, message = http.StatusBadRequest, "Two-factor authentication is not enabled"
+	case "two-factor enrollment not started":
+		status, message = http.StatusBadRequest, "Start enrollment first"
+	case "invalid two-factor code":
+		status, message = http.StatusBadRequest, "Invalid code"
+	case "invalid password":
+		status, message = http.StatusBadRequest, "Invalid password"
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.Error(logMessage, zap.Error(err))
+	}
+
+	resp := response.ErrorResponse{
+		Success: false,
+		Code:    status,
+		Error:   message,
+	}
+
+	c.JSON(status, resp)
+}
+
+func toRecoveryCodesResponse(codes []string) response.BaseRecoveryCodesResponse {
+	return response.BaseRecoveryCodesResponse{
+		Success: true,
+		Code:    http.StatusOK,
+		Data:    response.RecoveryCodes{RecoveryCodes: codes},
+	}
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func JWTMiddleware(jwtService services.JWTService) gin.HandlerFunc {
+// JWTMiddleware menerima JWT dari login maupun personal access token (diawali "tmp_").
+// JWT dari login juga dicek ke AuthService supaya token dari session yang sudah dicabut
+// atau dibuat sebelum ganti password ditolak.
+func JWTMiddleware(jwtService services.JWTService, tokenService services.APITokenService, authService services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.GetHeader("Authorization")
 		if !strings.HasPrefix(auth, "Bearer ") {
@@ -31,7 +34,18 @@ func JWTMiddleware(jwtService services.JWTService) gin.HandlerFunc {
 			return
 		}
 
-		claims, err := jwtService.ValidateToken(token)
+		var claims *domain.JWTClaims
+		var err error
+
+		if strings.HasPrefix(token, domain.APITokenPrefix) {
+			claims, err = tokenService.Authenticate(token)
+		} else {
+			claims, err = jwtService.ValidateToken(token)
+			if err == nil {
+				err = authService.CheckSession(claims)
+			}
+		}
+
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeAPITokens menerima token yang ada di map, token lain (dicabut, kedaluwarsa, tidak dikenal) ditolak.
+type fakeAPITokens struct {
+	services.APITokenService
+	active map[string][]string
+}
+
+func (f fakeAPITokens) Authenticate(plain string) (*domain.JWTClaims, error) {
+	scopes, ok := f.active[plain]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+
+	return &domain.JWTClaims{UserID: 1, Scopes: scopes}, nil
+}
+
+// fakeJWT menerima satu JWT dan mencatat apakah pernah dipanggil.
+type fakeJWT struct {
+	services.JWTService
+	calls *int
+}
+
+func (f fakeJWT) ValidateToken(token string) (*domain.JWTClaims, error) {
+	*f.calls++
+
+	if token != "login-jwt" {
+		return nil, errors.New("invalid token")
+	}
+
+	return &domain.JWTClaims{UserID: 2, Scopes: domain.DefaultUserScopes}, nil
+}
+
+type fakeSessions struct {
+	services.AuthService
+	err error
+}
+
+func (f fakeSessions) CheckSession(*domain.JWTClaims) error {
+	return f.err
+}
+
+func authEngine(jwtCalls *int, sessionErr error, required string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	tokens := fakeAPITokens{active: map[string][]string{
+		domain.APITokenPrefix + "reader": {domain.ScopeTasksRead},
+		domain.APITokenPrefix + "writer": {domain.ScopeTasksWrite},
+	}}
+
+	engine := gin.New()
+	engine.GET("/", JWTMiddleware(fakeJWT{calls: jwtCalls}, tokens, fakeSessions{err: sessionErr}), RequireScope(required), func(c *gin.Context) {
+		claims, _ := GetUserClaims(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": claims.UserID})
+	})
+
+	return engine
+}
+
+func TestJWTMiddlewareAcceptsAPITokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		required string
+		want     int
+		body     string
+	}{
+		{"active token", "Bearer tmp_reader", domain.ScopeTasksRead, http.StatusOK, `"user_id":1`},
+		{"write token reads", "Bearer tmp_writer", domain.ScopeTasksRead, http.StatusOK, `"user_id":1`},
+		{"read token cannot write", "Bearer tmp_reader", domain.ScopeTasksWrite, http.StatusForbidden, "insufficient scope"},
+		{"revoked or expired token", "Bearer tmp_revoked", domain.ScopeTasksRead, http.StatusUnauthorized, "invalid token"},
+		{"login jwt", "Bearer login-jwt", domain.ScopeTasksWrite, http.StatusOK, `"user_id":2`},
+		{"missing header", "", domain.ScopeTasksRead, http.StatusUnauthorized, "missing or malformed token"},
+		{"empty bearer", "Bearer ", domain.ScopeTasksRead, http.StatusUnauthorized, "empty authorization token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtCalls := 0
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			rec := httptest.NewRecorder()
+			authEngine(&jwtCalls, nil, tt.required).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want || !strings.Contains(rec.Body.String(), tt.body) {
+				t.Fatalf("status = %d, body = %q, want %d with %q", rec.Code, rec.Body.String(), tt.want, tt.body)
+			}
+
+			// token berawalan tmp_ tidak boleh jatuh ke validasi JWT
+			if strings.HasPrefix(tt.header, "Bearer "+domain.APITokenPrefix) && jwtCalls != 0 {
+				t.Errorf("api token was validated as a JWT")
+			}
+		})
+	}
+}
+
+func TestJWTMiddlewareRejectsRevokedSession(t *testing.T) {
+	jwtCalls := 0
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer login-jwt")
+
+	rec := httptest.NewRecorder()
+	authEngine(&jwtCalls, errors.New("session revoked"), domain.ScopeTasksRead).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "session revoked") {
+		t.Fatalf("status = %d, body = %q, want 401 session revoked", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"task-management/internal/infra/logger"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RateLimit membatasi request per user yang sudah login (dari GetUserClaims) atau per IP
+// untuk request anonim. Name memisahkan bucket antar grup route, misalnya "auth" dan "api".
+// Jika store gagal, request tetap diteruskan supaya API tidak ikut mati.
+func RateLimit(store repository.RateLimitStore, name string, rule domain.RateLimit) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		result, err := store.Take(name+":"+rateLimitKey(c), rule)
+		if err != nil {
+			logger.Error("rate limit store failed", zap.String("limiter", name), zap.Error(err))
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"code":    http.StatusTooManyRequests,
+				"error":   "rate limit exceeded",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitKey(c *gin.Context) string {
+	if claims, ok := GetUserClaims(c); ok {
+		return "user:" + strconv.FormatUint(uint64(claims.UserID), 10)
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
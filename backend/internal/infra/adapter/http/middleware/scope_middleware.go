@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope menolak request dengan 403 jika token tidak memiliki scope yang dibutuhkan.
+// Harus dipasang setelah JWTMiddleware.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, ok := GetUserClaims(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"code":    http.StatusUnauthorized,
+				"error":   "Unauthorized",
+			})
+			return
+		}
+
+		if !claims.HasScope(scope) {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"code":    http.StatusForbidden,
+				"error":   fmt.Sprintf("insufficient scope, %s is required", scope),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
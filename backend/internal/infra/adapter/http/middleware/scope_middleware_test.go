@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task-management/internal/domain"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func scopeEngine(scopes []string, required string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.POST("/", func(c *gin.Context) {
+		if scopes != nil {
+			c.Set("user", &domain.JWTClaims{UserID: 1, Scopes: scopes})
+		}
+	}, RequireScope(required), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	return engine
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		scopes   []string
+		required string
+		want     int
+	}{
+		{"read token cannot write", []string{domain.ScopeTasksRead}, domain.ScopeTasksWrite, http.StatusForbidden},
+		{"write token can read", []string{domain.ScopeTasksWrite}, domain.ScopeTasksRead, http.StatusNoContent},
+		{"profile token cannot read tasks", []string{domain.ScopeProfile}, domain.ScopeTasksRead, http.StatusForbidden},
+		{"admin needs admin scope", []string{domain.ScopeTasksWrite, domain.ScopeProfile}, domain.ScopeAdmin, http.StatusForbidden},
+		{"missing claims", nil, domain.ScopeTasksRead, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			scopeEngine(tt.scopes, tt.required).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+
+			if tt.want == http.StatusForbidden && !strings.Contains(rec.Header().Get("WWW-Authenticate"), `scope="`+tt.required+`"`) {
+				t.Errorf("WWW-Authenticate = %q, want the required scope", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
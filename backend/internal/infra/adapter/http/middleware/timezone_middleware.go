@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"task-management/internal/applications/ports/services"
+	"task-management/internal/infra/logger"
+	"task-management/internal/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TimezoneHeader mengganti zona waktu profil user untuk satu request, mis. "Asia/Jakarta".
+const TimezoneHeader = "X-Timezone"
+
+// Timezone menentukan zona waktu request: header X-Timezone, lalu zona waktu profil user,
+// lalu UTC. Zona ini dipakai untuk membaca filter tanggal dan menulis waktu di response.
+// Harus dipasang setelah JWTMiddleware.
+func Timezone(authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if name := c.GetHeader(TimezoneHeader); name != "" {
+			loc, err := utils.LoadLocation(name)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"code":    http.StatusBadRequest,
+					"error":   "invalid " + TimezoneHeader + " header, use an IANA time zone such as Asia/Jakarta",
+				})
+				return
+			}
+
+			c.Set("location", loc)
+			c.Next()
+			return
+		}
+
+		loc := time.UTC
+
+		if claims, ok := GetUserClaims(c); ok {
+			user, err := authService.Me(claims.UserID)
+			if err != nil {
+				logger.Warn("failed to load user timezone", zap.Uint("user_id", claims.UserID), zap.Error(err))
+			} else {
+				loc = utils.LocationOrUTC(user.Timezone)
+			}
+		}
+
+		c.Set("location", loc)
+		c.Next()
+	}
+}
+
+// GetLocation mengembalikan zona waktu request, UTC jika middleware Timezone tidak dipasang.
+func GetLocation(c *gin.Context) *time.Location {
+	if loc, ok := c.Get("location"); ok {
+		if location, ok := loc.(*time.Location); ok {
+			return location
+		}
+	}
+
+	return time.UTC
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"task-management/internal/applications/ports/services"
+	"task-management/internal/domain"
 	"task-management/internal/infra/adapter/http/handler"
 	"task-management/internal/infra/adapter/http/middleware"
 
@@ -10,35 +11,150 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRoutes(r *gin.Engine, authHandler *handler.AuthHandler, taskHandler *handler.TaskHandler, jwtSvc services.JWTService) {
+type Handlers struct {
+	Auth         *handler.AuthHandler
+	Task         *handler.TaskHandler
+	Notification *handler.NotificationHandler
+	Calendar     *handler.CalendarHandler
+	Import       *handler.ImportHandler
+	APIToken     *handler.APITokenHandler
+	TwoFactor    *handler.TwoFactorHandler
+	Password     *handler.PasswordHandler
+	JWKS         *handler.JWKSHandler
+	Session      *handler.SessionHandler
+	Admin        *handler.AdminHandler
+	Setup        *handler.SetupHandler
+	Profile      *handler.ProfileHandler
+	Account      *handler.AccountHandler
+	// OIDC nil jika login SSO tidak dikonfigurasi
+	OIDC *handler.OIDCHandler
+}
+
+// RateLimits adalah middleware rate limit per grup route, nil berarti tidak dibatasi.
+type RateLimits struct {
+	API  gin.HandlerFunc
+	Auth gin.HandlerFunc
+}
+
+func SetupRoutes(r *gin.Engine, h Handlers, jwtSvc services.JWTService, tokenSvc services.APITokenService, authSvc services.AuthService, limits RateLimits) {
 	api := r.Group("/api/v1")
 
+	readTasks := middleware.RequireScope(domain.ScopeTasksRead)
+	writeTasks := middleware.RequireScope(domain.ScopeTasksWrite)
+	profile := middleware.RequireScope(domain.ScopeProfile)
+	admin := middleware.RequireScope(domain.ScopeAdmin)
+	timezone := middleware.Timezone(authSvc)
+
 	// --- Auth Routes ---
 	authGroup := api.Group("/auth")
+	useIfSet(authGroup, limits.Auth)
 	{
-		authGroup.POST("/register", authHandler.Register)
-		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/register", h.Auth.Register)
+		authGroup.POST("/login", h.Auth.Login)
+		authGroup.POST("/login/2fa", h.Auth.LoginTwoFactor)
+		authGroup.POST("/password/forgot", h.Password.Forgot)
+		authGroup.POST("/password/reset", h.Password.Reset)
+		authGroup.POST("/email/verify", h.Profile.VerifyEmail)
+
+		if h.OIDC != nil {
+			authGroup.GET("/oidc/login", h.OIDC.Login)
+			authGroup.GET("/oidc/callback", h.OIDC.Callback)
+		}
 	}
 
+	// --- Setup admin pertama (bootstrap token, tanpa JWT) ---
+	setupGroup := api.Group("/setup")
+	useIfSet(setupGroup, limits.Auth)
+	setupGroup.POST("/admin", h.Setup.CreateAdmin)
+
+	// --- Calendar Feed (token di URL, tanpa JWT) ---
+	calendarGroup := api.Group("/calendar")
+	useIfSet(calendarGroup, limits.API)
+	calendarGroup.GET("/:token", h.Calendar.Feed)
+
+	// --- Avatar (publik supaya bisa dipakai langsung di tag img) ---
+	usersGroup := api.Group("/users")
+	useIfSet(usersGroup, limits.API)
+	usersGroup.GET("/:id/avatar", h.Profile.Avatar)
+
 	// --- Protected Routes ---
 	protectedGroup := api.Group("/")
-	protectedGroup.Use(middleware.JWTMiddleware(jwtSvc))
+	protectedGroup.Use(middleware.JWTMiddleware(jwtSvc, tokenSvc, authSvc))
+	// rate limit dipasang setelah JWT supaya bucket dihitung per user
+	useIfSet(protectedGroup, limits.API)
 	{
 		// User profile
-		protectedGroup.GET("/profile", authHandler.Me)
+		profileGroup := protectedGroup.Group("/profile", profile)
+		{
+			profileGroup.GET("", h.Auth.Me)
+			profileGroup.PUT("", h.Profile.Update)
+			profileGroup.DELETE("", h.Account.Delete)
+			profileGroup.GET("/export", h.Account.Export)
+			profileGroup.PUT("/avatar", h.Profile.UploadAvatar)
+			profileGroup.DELETE("/avatar", h.Profile.DeleteAvatar)
+			profileGroup.PUT("/password", h.Password.Change)
+			profileGroup.GET("/sessions", h.Session.List)
+			profileGroup.DELETE("/sessions/:id", h.Session.Revoke)
+			profileGroup.GET("/notification-preferences", h.Notification.GetPreferences)
+			profileGroup.PUT("/notification-preferences", h.Notification.UpdatePreferences)
+			profileGroup.POST("/calendar-token", h.Calendar.CreateToken)
+			profileGroup.DELETE("/calendar-token", h.Calendar.RevokeToken)
+
+			// Personal access tokens
+			profileGroup.POST("/tokens", h.APIToken.Create)
+			profileGroup.GET("/tokens", h.APIToken.List)
+			profileGroup.DELETE("/tokens/:id", h.APIToken.Revoke)
+
+			// Two-factor authentication
+			profileGroup.POST("/2fa/enroll", h.TwoFactor.Enroll)
+			profileGroup.POST("/2fa/enable", h.TwoFactor.Enable)
+			profileGroup.POST("/2fa/disable", h.TwoFactor.Disable)
+			profileGroup.POST("/2fa/recovery-codes", h.TwoFactor.RegenerateRecoveryCodes)
+		}
 
 		// Task routes
-		taskGroup := protectedGroup.Group("/tasks")
+		taskGroup := protectedGroup.Group("/tasks", timezone)
+		{
+			taskGroup.POST("/", writeTasks, h.Task.Create)
+			taskGroup.GET("/", readTasks, h.Task.Get)
+			taskGroup.GET("/export", readTasks, h.Task.Export)
+			taskGroup.POST("/import", writeTasks, h.Task.Import)
+			taskGroup.POST("/import/:source", writeTasks, h.Import.Import)
+			taskGroup.GET("/:id", readTasks, h.Task.GetByID)
+			taskGroup.PUT("/:id", writeTasks, h.Task.Update)
+			taskGroup.DELETE("/:id", writeTasks, h.Task.Delete)
+		}
+
+		// Notification routes
+		notificationGroup := protectedGroup.Group("/notifications", timezone)
+		{
+			notificationGroup.GET("/", readTasks, h.Notification.Get)
+			notificationGroup.POST("/read-all", writeTasks, h.Notification.MarkAllRead)
+			notificationGroup.POST("/:id/read", writeTasks, h.Notification.MarkRead)
+		}
+
+		// Admin routes, hanya token dengan scope admin
+		adminGroup := protectedGroup.Group("/admin", admin)
 		{
-			taskGroup.POST("/", taskHandler.Create)
-			taskGroup.GET("/", taskHandler.Get)
-			taskGroup.GET("/:id", taskHandler.GetByID)
-			taskGroup.PUT("/:id", taskHandler.Update)
-			taskGroup.DELETE("/:id", taskHandler.Delete)
+			adminGroup.GET("/users", h.Admin.ListUsers)
+			adminGroup.GET("/users/:id", h.Admin.GetUser)
+			adminGroup.POST("/users/:id/deactivate", h.Admin.Deactivate)
+			adminGroup.POST("/users/:id/reactivate", h.Admin.Reactivate)
+			adminGroup.POST("/users/:id/reset-password", h.Admin.ResetPassword)
+			adminGroup.DELETE("/users/:id", h.Admin.Delete)
 		}
 	}
 
+	// --- Public key JWT untuk service lain ---
+	r.GET("/.well-known/jwks.json", h.JWKS.Get)
+
 	// --- Swagger ---
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 }
+
+func useIfSet(group *gin.RouterGroup, mw gin.HandlerFunc) {
+	if mw != nil {
+		group.Use(mw)
+	}
+}
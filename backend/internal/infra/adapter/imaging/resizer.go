@@ -0,0 +1,115 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"task-management/internal/applications/ports/services"
+)
+
+// maxPixels membatasi ukuran gambar yang di-decode supaya file kecil dengan dimensi
+// sangat besar tidak menghabiskan memory.
+const maxPixels = 40_000_000
+
+var ErrInvalidImage = errors.New("unsupported or invalid image")
+
+// Resizer mengubah ukuran gambar JPEG, PNG atau GIF dengan pustaka standar.
+type Resizer struct{}
+
+func NewResizer() services.ImageResizer {
+	return &Resizer{}
+}
+
+// ResizeSquare implements services.ImageResizer.
+func (r *Resizer) ResizeSquare(data []byte, sizes []int) (map[int][]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrInvalidImage
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	src := squareCrop(img)
+
+	result := make(map[int][]byte, len(sizes))
+	for _, size := range sizes {
+		var buf bytes.Buffer
+
+		if err := png.Encode(&buf, scale(src, size)); err != nil {
+			return nil, err
+		}
+
+		result[size] = buf.Bytes()
+	}
+
+	return result, nil
+}
+
+// squareCrop mengambil persegi terbesar di tengah gambar sebagai RGBA.
+func squareCrop(img image.Image) *image.RGBA {
+	b := img.Bounds()
+
+	side := min(b.Dx(), b.Dy())
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(dst, dst.Bounds(), img, image.Pt(x0, y0), draw.Src)
+
+	return dst
+}
+
+// scale mengubah ukuran gambar persegi dengan rata-rata area (box filter). Saat memperbesar,
+// setiap piksel tujuan mengambil piksel sumber terdekat.
+func scale(src *image.RGBA, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	side := src.Bounds().Dx()
+
+	for dy := 0; dy < size; dy++ {
+		sy0, sy1 := span(dy, size, side)
+
+		for dx := 0; dx < size; dx++ {
+			sx0, sx1 := span(dx, size, side)
+
+			var r, g, b, a, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := sx0; sx < sx1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					b += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+
+			i := dy*dst.Stride + dx*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+
+	return dst
+}
+
+// span mengembalikan rentang piksel sumber [start, end) untuk piksel tujuan d, minimal satu piksel.
+func span(d, size, side int) (int, int) {
+	start := d * side / size
+	end := (d + 1) * side / size
+
+	if end <= start {
+		end = start + 1
+	}
+
+	return start, end
+}
@@ -0,0 +1,126 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// stripes membuat gambar 3 kolom merah, hijau, biru supaya hasil crop tengah bisa dicek.
+func stripes(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	colors := []color.RGBA{{R: 255, A: 255}, {G: 255, A: 255}, {B: 255, A: 255}}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, colors[x*3/width])
+		}
+	}
+
+	return img
+}
+
+func encode(t *testing.T, img image.Image, format string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var err error
+
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	default:
+		err = png.Encode(&buf, img)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestResizeSquareDimensions(t *testing.T) {
+	sizes := []int{32, 64, 128, 256}
+
+	for _, format := range []string{"png", "jpeg", "gif"} {
+		t.Run(format, func(t *testing.T) {
+			resized, err := NewResizer().ResizeSquare(encode(t, stripes(300, 100), format), sizes)
+			if err != nil {
+				t.Fatalf("ResizeSquare: %v", err)
+			}
+
+			if len(resized) != len(sizes) {
+				t.Fatalf("got %d sizes, want %d", len(resized), len(sizes))
+			}
+
+			for _, size := range sizes {
+				img, kind, err := image.Decode(bytes.NewReader(resized[size]))
+				if err != nil || kind != "png" {
+					t.Fatalf("size %d: %s, %v, want png", size, kind, err)
+				}
+
+				if b := img.Bounds(); b.Dx() != size || b.Dy() != size {
+					t.Errorf("size %d is %dx%d", size, b.Dx(), b.Dy())
+				}
+
+				// 300x100 dipotong ke persegi tengah, jadi hanya kolom hijau yang tersisa
+				r, g, b, _ := img.At(size/2, size/2).RGBA()
+				if r>>8 > 40 || g>>8 < 200 || b>>8 > 40 {
+					t.Errorf("size %d center = %d,%d,%d, want the green middle", size, r>>8, g>>8, b>>8)
+				}
+			}
+		})
+	}
+}
+
+func TestResizeSquareUpscalesSmallImages(t *testing.T) {
+	resized, err := NewResizer().ResizeSquare(encode(t, stripes(9, 9), "png"), []int{256})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := png.DecodeConfig(bytes.NewReader(resized[256]))
+	if err != nil || cfg.Width != 256 || cfg.Height != 256 {
+		t.Fatalf("config = %+v, %v, want 256x256", cfg, err)
+	}
+}
+
+// withDimensions mengganti lebar dan tinggi di header IHDR PNG dan menghitung ulang CRC-nya.
+func withDimensions(data []byte, width, height uint32) []byte {
+	patched := append([]byte{}, data...)
+
+	binary.BigEndian.PutUint32(patched[16:20], width)
+	binary.BigEndian.PutUint32(patched[20:24], height)
+	binary.BigEndian.PutUint32(patched[29:33], crc32.ChecksumIEEE(patched[12:29]))
+
+	return patched
+}
+
+func TestResizeSquareRejectsInvalidImages(t *testing.T) {
+	small := encode(t, stripes(10, 10), "png")
+
+	tests := map[string][]byte{
+		"empty":           nil,
+		"text":            []byte("GIF89a is not enough"),
+		"svg":             []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="10" height="10"/>`),
+		"truncated":       small[:len(small)/2],
+		"too many pixels": withDimensions(small, 10000, 10000),
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewResizer().ResizeSquare(data, []int{32}); err != ErrInvalidImage {
+				t.Fatalf("ResizeSquare = %v, want ErrInvalidImage", err)
+			}
+		})
+	}
+}
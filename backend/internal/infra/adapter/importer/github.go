@@ -0,0 +1,85 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"task-management/internal/domain"
+	"time"
+)
+
+// GitHubAdapter membaca array issue dalam format JSON dari REST API GitHub
+// (mis. hasil `gh api repos/{owner}/{repo}/issues --paginate`) atau `gh issue list --json ...`.
+// Pull request ikut muncul di endpoint issues dan dilewati.
+type GitHubAdapter struct{}
+
+type githubIssue struct {
+	Number      int             `json:"number"`
+	URL         string          `json:"html_url"`
+	CLIURL      string          `json:"url"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"`
+	PullRequest json.RawMessage `json:"pull_request"`
+	Labels      []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Milestone *struct {
+		DueOn *time.Time `json:"due_on"`
+	} `json:"milestone"`
+}
+
+func (a *GitHubAdapter) Source() string {
+	return "github"
+}
+
+func (a *GitHubAdapter) Parse(r io.Reader, _ *time.Location) ([]domain.ExternalTask, error) {
+	var issues []githubIssue
+	if err := json.NewDecoder(r).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("invalid GitHub issues export: %w", err)
+	}
+
+	tasks := make([]domain.ExternalTask, 0, len(issues))
+
+	for _, issue := range issues {
+		if len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null" {
+			continue
+		}
+
+		// nomor issue hanya unik per repository, jadi URL dipakai sebagai ID jika tersedia
+		externalID := issue.URL
+		if externalID == "" {
+			externalID = issue.CLIURL
+		}
+		if externalID == "" {
+			externalID = strconv.Itoa(issue.Number)
+		}
+
+		task := domain.ExternalTask{
+			ExternalID:  externalID,
+			Title:       issue.Title,
+			Description: issue.Body,
+			Status:      issue.State,
+		}
+
+		for _, l := range issue.Labels {
+			task.Labels = append(task.Labels, l.Name)
+		}
+
+		for _, as := range issue.Assignees {
+			task.Assignees = append(task.Assignees, as.Login)
+		}
+
+		if issue.Milestone != nil {
+			task.Deadline = issue.Milestone.DueOn
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
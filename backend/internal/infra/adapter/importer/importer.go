@@ -0,0 +1,33 @@
+package importer
+
+import (
+	"io"
+	"task-management/internal/domain"
+	"time"
+)
+
+// Adapter membaca file export dari tool lain dan mengubahnya menjadi domain.ExternalTask.
+// Adapter hanya membaca file, tidak pernah memanggil API eksternal.
+// loc dipakai untuk tanggal tanpa zona waktu (mis. "2006-01-02" di export Jira).
+type Adapter interface {
+	Source() string
+	Parse(r io.Reader, loc *time.Location) ([]domain.ExternalTask, error)
+}
+
+var adapters = map[string]Adapter{}
+
+func register(a Adapter) {
+	adapters[a.Source()] = a
+}
+
+func init() {
+	register(&TrelloAdapter{})
+	register(&JiraAdapter{})
+	register(&GitHubAdapter{})
+}
+
+// Get mengembalikan adapter untuk source tertentu (trello, jira, github).
+func Get(source string) (Adapter, bool) {
+	a, ok := adapters[source]
+	return a, ok
+}
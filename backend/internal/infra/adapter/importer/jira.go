@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"task-management/internal/domain"
+	"time"
+)
+
+// JiraAdapter membaca export CSV dari Jira ("Export > Export CSV (all fields)").
+// Jira menulis kolom yang bisa berisi banyak nilai (mis. Labels) sebagai kolom berulang
+// dengan nama yang sama, jadi semua kolom dengan nama tersebut digabung.
+type JiraAdapter struct{}
+
+var jiraDateLayouts = []string{
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	time.RFC3339,
+}
+
+func (a *JiraAdapter) Source() string {
+	return "jira"
+}
+
+func (a *JiraAdapter) Parse(r io.Reader, loc *time.Location) ([]domain.ExternalTask, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Jira export: %w", err)
+	}
+
+	columns := map[string][]int{}
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(name))
+		columns[key] = append(columns[key], i)
+	}
+
+	if len(columns["issue key"]) == 0 || len(columns["summary"]) == 0 {
+		return nil, errors.New("invalid Jira export: Issue key and Summary columns are required")
+	}
+
+	values := func(record []string, name string) []string {
+		var out []string
+		for _, i := range columns[name] {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				out = append(out, strings.TrimSpace(record[i]))
+			}
+		}
+		return out
+	}
+
+	first := func(record []string, name string) string {
+		if v := values(record, name); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	var tasks []domain.ExternalTask
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid Jira export: %w", err)
+		}
+
+		task := domain.ExternalTask{
+			ExternalID:  first(record, "issue key"),
+			Title:       first(record, "summary"),
+			Description: first(record, "description"),
+			Status:      first(record, "status"),
+			Labels:      values(record, "labels"),
+			Assignees:   values(record, "assignee"),
+			Deadline:    parseJiraDate(first(record, "due date"), loc),
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func parseJiraDate(value string, loc *time.Location) *time.Time {
+	if value == "" {
+		return nil
+	}
+
+	for _, layout := range jiraDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return &t
+		}
+	}
+
+	return nil
+}
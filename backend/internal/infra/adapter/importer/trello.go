@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"task-management/internal/domain"
+	"time"
+)
+
+// TrelloAdapter membaca file JSON dari menu "Print and export > Export as JSON" di board Trello.
+// Status diambil dari nama list tempat card berada.
+type TrelloAdapter struct{}
+
+type trelloBoard struct {
+	Lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"lists"`
+	Labels []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	} `json:"labels"`
+	Members []struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Cards []struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Desc        string     `json:"desc"`
+		IDList      string     `json:"idList"`
+		IDLabels    []string   `json:"idLabels"`
+		IDMembers   []string   `json:"idMembers"`
+		Due         *time.Time `json:"due"`
+		DueComplete bool       `json:"dueComplete"`
+		Closed      bool       `json:"closed"`
+	} `json:"cards"`
+}
+
+func (a *TrelloAdapter) Source() string {
+	return "trello"
+}
+
+func (a *TrelloAdapter) Parse(r io.Reader, _ *time.Location) ([]domain.ExternalTask, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return nil, fmt.Errorf("invalid Trello export: %w", err)
+	}
+
+	lists := map[string]string{}
+	for _, l := range board.Lists {
+		lists[l.ID] = l.Name
+	}
+
+	labels := map[string]string{}
+	for _, l := range board.Labels {
+		name := l.Name
+		if name == "" {
+			name = l.Color
+		}
+		labels[l.ID] = name
+	}
+
+	members := map[string]string{}
+	for _, m := range board.Members {
+		members[m.ID] = m.Username
+	}
+
+	tasks := make([]domain.ExternalTask, 0, len(board.Cards))
+
+	for _, card := range board.Cards {
+		// card yang diarsipkan tidak ikut diimport
+		if card.Closed {
+			continue
+		}
+
+		status := lists[card.IDList]
+		if card.DueComplete {
+			status = "done"
+		}
+
+		task := domain.ExternalTask{
+			ExternalID:  card.ID,
+			Title:       card.Name,
+			Description: card.Desc,
+			Status:      status,
+			Deadline:    card.Due,
+		}
+
+		for _, id := range card.IDLabels {
+			if name := labels[id]; name != "" {
+				task.Labels = append(task.Labels, name)
+			}
+		}
+
+		for _, id := range card.IDMembers {
+			if username := members[id]; username != "" {
+				task.Assignees = append(task.Assignees, username)
+			}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,73 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type apiTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) repository.APITokenRepository {
+	return &apiTokenRepository{db: db}
+}
+
+// Create implements repository.APITokenRepository.
+func (r *apiTokenRepository) Create(token *domain.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByID implements repository.APITokenRepository.
+func (r *apiTokenRepository) FindByID(id uint) (*domain.APIToken, error) {
+	var token domain.APIToken
+	if err := r.db.First(&token, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// FindByHash implements repository.APITokenRepository.
+func (r *apiTokenRepository) FindByHash(tokenHash string) (*domain.APIToken, error) {
+	var token domain.APIToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// ListByUser implements repository.APITokenRepository.
+func (r *apiTokenRepository) ListByUser(userID uint) ([]domain.APIToken, error) {
+	var tokens []domain.APIToken
+
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// Revoke implements repository.APITokenRepository.
+func (r *apiTokenRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&domain.APIToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// TouchLastUsed implements repository.APITokenRepository.
+func (r *apiTokenRepository) TouchLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&domain.APIToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", at).Error
+}
@@ -0,0 +1,50 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) repository.CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+// FindByUser implements repository.CalendarFeedRepository.
+func (r *calendarFeedRepository) FindByUser(userID uint) (*domain.CalendarFeed, error) {
+	return r.findOne("user_id = ?", userID)
+}
+
+// FindByTokenHash implements repository.CalendarFeedRepository.
+func (r *calendarFeedRepository) FindByTokenHash(tokenHash string) (*domain.CalendarFeed, error) {
+	return r.findOne("token_hash = ?", tokenHash)
+}
+
+// Save implements repository.CalendarFeedRepository.
+func (r *calendarFeedRepository) Save(feed *domain.CalendarFeed) error {
+	return r.db.Save(feed).Error
+}
+
+// DeleteByUser implements repository.CalendarFeedRepository.
+func (r *calendarFeedRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&domain.CalendarFeed{}).Error
+}
+
+func (r *calendarFeedRepository) findOne(query string, args ...any) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := r.db.Where(query, args...).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,51 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type emailVerificationTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewEmailVerificationTokenRepository(db *gorm.DB) repository.EmailVerificationTokenRepository {
+	return &emailVerificationTokenRepository{db: db}
+}
+
+// Create implements repository.EmailVerificationTokenRepository.
+func (r *emailVerificationTokenRepository) Create(token *domain.EmailVerificationToken) error {
+	return r.db.Create(token).Error
+}
+
+// Take implements repository.EmailVerificationTokenRepository.
+func (r *emailVerificationTokenRepository) Take(tokenHash string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// jika request lain sudah memakai token ini lebih dulu, anggap tidak ditemukan
+	result := r.db.Where("id = ?", token.ID).Delete(&domain.EmailVerificationToken{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &token, nil
+}
+
+// DeleteByUser implements repository.EmailVerificationTokenRepository.
+func (r *emailVerificationTokenRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&domain.EmailVerificationToken{}).Error
+}
@@ -0,0 +1,40 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type externalTaskLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalTaskLinkRepository(db *gorm.DB) repository.ExternalTaskLinkRepository {
+	return &externalTaskLinkRepository{db: db}
+}
+
+// Find implements repository.ExternalTaskLinkRepository.
+func (r *externalTaskLinkRepository) Find(importedBy uint, source, externalID string) (*domain.ExternalTaskLink, error) {
+	var link domain.ExternalTaskLink
+
+	err := r.db.Where("imported_by = ? AND source = ? AND external_id = ?", importedBy, source, externalID).
+		First(&link).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// Save implements repository.ExternalTaskLinkRepository.
+func (r *externalTaskLinkRepository) Save(link *domain.ExternalTaskLink) error {
+	return r.db.Save(link).Error
+}
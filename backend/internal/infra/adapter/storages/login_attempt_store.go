@@ -0,0 +1,140 @@
+package storages
+
+import (
+	"errors"
+	"strings"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loginAttemptStore struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptStore(db *gorm.DB) repository.LoginAttemptStore {
+	return &loginAttemptStore{db: db}
+}
+
+// Get implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Get(key string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	if err := s.db.Where("attempt_key = ?", key).First(&attempt).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// Increment implements repository.LoginAttemptStore.
+// Counter dinaikkan dengan UPDATE failures = failures + 1 supaya percobaan paralel tidak saling
+// menimpa. Kolom first_failure di-SET paling akhir karena MySQL memakai nilai yang sudah diubah
+// untuk kolom berikutnya di klausa SET yang sama.
+func (s *loginAttemptStore) Increment(key string, now time.Time, window time.Duration) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	expired := now.Add(-window)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		update := func() (int64, error) {
+			result := tx.Exec(`UPDATE login_attempts SET
+				failures = CASE WHEN first_failure < ? THEN 1 ELSE failures + 1 END,
+				locked_until = CASE WHEN first_failure < ? THEN NULL ELSE locked_until END,
+				last_failure = ?,
+				first_failure = CASE WHEN first_failure < ? THEN ? ELSE first_failure END
+				WHERE attempt_key = ?`, expired, expired, now, expired, now, key)
+
+			return result.RowsAffected, result.Error
+		}
+
+		updated, err := update()
+		if err != nil {
+			return err
+		}
+
+		if updated == 0 {
+			// percobaan pertama, insert paralel yang kalah mengulang UPDATE
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.LoginAttempt{
+				Key:          key,
+				Failures:     1,
+				FirstFailure: now,
+				LastFailure:  now,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+
+			if result.RowsAffected == 0 {
+				if _, err := update(); err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Where("attempt_key = ?", key).First(&attempt).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &attempt, nil
+}
+
+// Decrement implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Decrement(key string) error {
+	return s.db.Model(&domain.LoginAttempt{}).
+		Where("attempt_key = ? AND failures > 0", key).
+		Update("failures", gorm.Expr("failures - 1")).Error
+}
+
+// Lock implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Lock(key string, until time.Time) error {
+	return s.db.Model(&domain.LoginAttempt{}).
+		Where("attempt_key = ?", key).
+		Update("locked_until", until).Error
+}
+
+// Delete implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Delete(key string) error {
+	return s.db.Where("attempt_key = ?", key).Delete(&domain.LoginAttempt{}).Error
+}
+
+// Prune implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Prune(before time.Time) error {
+	return s.db.Where("last_failure < ?", before).
+		Where("locked_until IS NULL OR locked_until < ?", before).
+		Delete(&domain.LoginAttempt{}).Error
+}
+
+type loginAuditRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAuditRepository(db *gorm.DB) repository.LoginAuditRepository {
+	return &loginAuditRepository{db: db}
+}
+
+// Create implements repository.LoginAuditRepository.
+func (r *loginAuditRepository) Create(audit *domain.LoginAudit) error {
+	return r.db.Create(audit).Error
+}
+
+// ListByUsername implements repository.LoginAuditRepository.
+func (r *loginAuditRepository) ListByUsername(username string) ([]domain.LoginAudit, error) {
+	var audits []domain.LoginAudit
+
+	err := r.db.Where("LOWER(username) = ?", strings.ToLower(username)).Order("created_at, id").Find(&audits).Error
+	return audits, err
+}
+
+// DeleteByUsername implements repository.LoginAuditRepository.
+func (r *loginAuditRepository) DeleteByUsername(username string) error {
+	return r.db.Where("LOWER(username) = ?", strings.ToLower(username)).Delete(&domain.LoginAudit{}).Error
+}
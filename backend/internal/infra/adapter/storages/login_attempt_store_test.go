@@ -0,0 +1,46 @@
+package storages
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginAttemptStoreIncrement(t *testing.T) {
+	store := NewLoginAttemptStore(openTestDB(t))
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	for want := 1; want <= 3; want++ {
+		attempt, err := store.Increment("user:alice", now, 15*time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if attempt.Failures != want {
+			t.Fatalf("failures = %d, want %d", attempt.Failures, want)
+		}
+	}
+
+	if err := store.Lock("user:alice", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Decrement("user:alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	attempt, _ := store.Get("user:alice")
+	if attempt.Failures != 2 || attempt.LockedUntil == nil {
+		t.Fatalf("after decrement = %+v, want 2 failures and locked", attempt)
+	}
+
+	// kegagalan pertama sudah di luar window, counter dan kunci dimulai ulang
+	later := now.Add(20 * time.Minute)
+	attempt, err := store.Increment("user:alice", later, 15*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if attempt.Failures != 1 || attempt.LockedUntil != nil || !attempt.FirstFailure.Equal(later) {
+		t.Fatalf("after window = %+v, want fresh counter", attempt)
+	}
+}
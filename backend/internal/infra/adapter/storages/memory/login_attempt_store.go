@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+)
+
+type loginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]domain.LoginAttempt
+}
+
+// NewLoginAttemptStore menyimpan counter di memory proses. Counter hilang saat restart
+// dan tidak dibagi antar instance, pakai store DB jika server dijalankan lebih dari satu.
+func NewLoginAttemptStore() repository.LoginAttemptStore {
+	return &loginAttemptStore{attempts: map[string]domain.LoginAttempt{}}
+}
+
+// Get implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Get(key string) (*domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+
+	return &attempt, nil
+}
+
+// Increment implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Increment(key string, now time.Time, window time.Duration) (*domain.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || now.Sub(attempt.FirstFailure) > window {
+		attempt = domain.LoginAttempt{Key: key, FirstFailure: now}
+	}
+
+	attempt.Failures++
+	attempt.LastFailure = now
+	s.attempts[key] = attempt
+
+	return &attempt, nil
+}
+
+// Decrement implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Decrement(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[key]; ok && attempt.Failures > 0 {
+		attempt.Failures--
+		s.attempts[key] = attempt
+	}
+
+	return nil
+}
+
+// Lock implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.attempts[key]; ok {
+		attempt.LockedUntil = &until
+		s.attempts[key] = attempt
+	}
+
+	return nil
+}
+
+// Delete implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// Prune implements repository.LoginAttemptStore.
+func (s *loginAttemptStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, attempt := range s.attempts {
+		if attempt.LastFailure.Before(before) && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(before)) {
+			delete(s.attempts, key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"math"
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+type rateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimitStore menyimpan bucket di memory proses. Limit tidak dibagi antar instance,
+// pakai store Redis jika server dijalankan lebih dari satu.
+func NewRateLimitStore() repository.RateLimitStore {
+	return &rateLimitStore{
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Take implements repository.RateLimitStore.
+func (s *rateLimitStore) Take(key string, rule domain.RateLimit) (*domain.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now, rule.Period)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Limit), updated: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updated)
+	if elapsed > 0 {
+		refill := elapsed.Seconds() / rule.Period.Seconds() * float64(rule.Limit)
+		b.tokens = math.Min(float64(rule.Limit), b.tokens+refill)
+		b.updated = now
+	}
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	result := domain.NewRateLimitResult(rule, b.tokens, allowed)
+	b.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep membuang bucket yang sudah penuh kembali, paling sering sekali per period.
+func (s *rateLimitStore) sweep(now time.Time, period time.Duration) {
+	if now.Sub(s.lastSweep) < period {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if !b.full.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastSweep = now
+}
@@ -0,0 +1,319 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type taskRepository struct {
+	mu     sync.RWMutex
+	tasks  map[uint]domain.Task
+	nextID uint
+}
+
+// NewTaskRepository menyimpan task di memory proses dengan perilaku yang sama seperti repository gorm.
+// Data hilang saat restart, dipakai untuk demo dan integration test tanpa database.
+func NewTaskRepository() repository.TaskRepository {
+	return &taskRepository{tasks: map[uint]domain.Task{}, nextID: 1}
+}
+
+// Create implements repository.TaskRepository.
+func (t *taskRepository) Create(task *domain.Task) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.insert(task)
+	return nil
+}
+
+// CreateBatch implements repository.TaskRepository.
+func (t *taskRepository) CreateBatch(tasks []domain.Task) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range tasks {
+		t.insert(&tasks[i])
+	}
+
+	return nil
+}
+
+// insert mengisi ID, CreatedAt dan status default seperti insert gorm, mu harus sudah di-lock.
+func (t *taskRepository) insert(task *domain.Task) {
+	if task.ID == 0 {
+		task.ID = t.nextID
+	}
+
+	if task.ID >= t.nextID {
+		t.nextID = task.ID + 1
+	}
+
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now().UTC()
+	}
+
+	if task.Status == "" {
+		task.Status = domain.ToDo
+	}
+
+	t.tasks[task.ID] = copyTask(*task)
+}
+
+// Delete implements repository.TaskRepository.
+func (t *taskRepository) Delete(id uint) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tasks, id)
+	return nil
+}
+
+// GetByID implements repository.TaskRepository.
+// Sama seperti repository gorm, task yang tidak ada mengembalikan gorm.ErrRecordNotFound
+// karena service memeriksa error tersebut.
+func (t *taskRepository) GetByID(id uint) (*domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	task, ok := t.tasks[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	task = copyTask(task)
+	return &task, nil
+}
+
+// GetByUser implements repository.TaskRepository.
+func (t *taskRepository) GetByUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.byUser(userID, status, dueBefore), nil
+}
+
+// StreamByUser implements repository.TaskRepository.
+// fn dipanggil setelah lock dilepas sehingga fn boleh memakai repository ini.
+func (t *taskRepository) StreamByUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time, fn func(task *domain.Task) error) error {
+	t.mu.RLock()
+	tasks := t.byUser(userID, status, dueBefore)
+	t.mu.RUnlock()
+
+	for i := range tasks {
+		if err := fn(&tasks[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// byUser mengikuti query gorm: dengan dueBefore hanya task yang punya deadline sebelum dueBefore,
+// urut deadline, tanpa dueBefore urut waktu dibuat.
+func (t *taskRepository) byUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time) []domain.Task {
+	tasks := t.filter(func(task *domain.Task) bool {
+		if task.UserID != userID {
+			return false
+		}
+
+		if status != nil && task.Status != *status {
+			return false
+		}
+
+		return dueBefore == nil || (task.Deadline != nil && task.Deadline.Before(*dueBefore))
+	})
+
+	if dueBefore != nil {
+		sortByTime(tasks, func(task *domain.Task) time.Time { return *task.Deadline })
+	} else {
+		sortByTime(tasks, func(task *domain.Task) time.Time { return task.CreatedAt })
+	}
+
+	return tasks
+}
+
+// GetDueBetween implements repository.TaskRepository.
+func (t *taskRepository) GetDueBetween(from, to time.Time) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := t.filter(func(task *domain.Task) bool {
+		return task.Status != domain.Done && between(task.Deadline, from, to)
+	})
+
+	sortByTime(tasks, func(task *domain.Task) time.Time { return *task.Deadline })
+	return tasks, nil
+}
+
+// GetOverdueByUser implements repository.TaskRepository.
+func (t *taskRepository) GetOverdueByUser(userID uint, now time.Time) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := t.filter(func(task *domain.Task) bool {
+		return task.UserID == userID && task.Status != domain.Done && task.Deadline != nil && task.Deadline.Before(now)
+	})
+
+	sortByTime(tasks, func(task *domain.Task) time.Time { return *task.Deadline })
+	return tasks, nil
+}
+
+// GetDueBetweenByUser implements repository.TaskRepository.
+func (t *taskRepository) GetDueBetweenByUser(userID uint, from, to time.Time) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := t.filter(func(task *domain.Task) bool {
+		return task.UserID == userID && task.Status != domain.Done && between(task.Deadline, from, to)
+	})
+
+	sortByTime(tasks, func(task *domain.Task) time.Time { return *task.Deadline })
+	return tasks, nil
+}
+
+// GetCompletedBetweenByUser implements repository.TaskRepository.
+func (t *taskRepository) GetCompletedBetweenByUser(userID uint, from, to time.Time) ([]domain.Task, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	tasks := t.filter(func(task *domain.Task) bool {
+		return task.UserID == userID && task.Status == domain.Done && between(task.CompletedAt, from, to)
+	})
+
+	sortByTime(tasks, func(task *domain.Task) time.Time { return *task.CompletedAt })
+	return tasks, nil
+}
+
+// Update implements repository.TaskRepository.
+// Sama seperti Save di gorm, task tanpa ID atau yang belum ada akan di-insert.
+func (t *taskRepository) Update(task *domain.Task) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.tasks[task.ID]; !ok {
+		t.insert(task)
+		return nil
+	}
+
+	t.tasks[task.ID] = copyTask(*task)
+	return nil
+}
+
+// CountByUsers implements repository.TaskRepository.
+func (t *taskRepository) CountByUsers(userIDs []uint) (map[uint]int64, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	counts := make(map[uint]int64, len(userIDs))
+
+	wanted := make(map[uint]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+
+	for _, task := range t.tasks {
+		if wanted[task.UserID] {
+			counts[task.UserID]++
+		}
+	}
+
+	return counts, nil
+}
+
+// CountByStatus implements repository.TaskRepository.
+func (t *taskRepository) CountByStatus(userID uint) (map[domain.TaskStatus]int64, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	counts := map[domain.TaskStatus]int64{}
+	for _, task := range t.tasks {
+		if task.UserID == userID {
+			counts[task.Status]++
+		}
+	}
+
+	return counts, nil
+}
+
+// lookup mengembalikan salinan task dengan id, nil jika tidak ada.
+func (t *taskRepository) lookup(id uint) *domain.Task {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	task, ok := t.tasks[id]
+	if !ok {
+		return nil
+	}
+
+	task = copyTask(task)
+	return &task
+}
+
+// restore mengembalikan task dengan id ke previous, previous nil berarti task dihapus.
+// nextID tidak dikembalikan supaya ID yang sudah dibagikan tidak dipakai lagi, sama seperti auto increment.
+func (t *taskRepository) restore(id uint, previous *domain.Task) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if previous == nil {
+		delete(t.tasks, id)
+		return
+	}
+
+	t.tasks[id] = copyTask(*previous)
+}
+
+// filter mengembalikan salinan task yang cocok urut ID, mu harus sudah di-lock.
+func (t *taskRepository) filter(match func(task *domain.Task) bool) []domain.Task {
+	var tasks []domain.Task
+
+	for _, task := range t.tasks {
+		if match(&task) {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+
+	return tasks
+}
+
+// sortByTime mengurutkan ascending, task dengan waktu sama tetap urut ID.
+func sortByTime(tasks []domain.Task, key func(task *domain.Task) time.Time) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return key(&tasks[i]).Before(key(&tasks[j]))
+	})
+}
+
+// between sama dengan BETWEEN di SQL: inklusif di kedua ujung dan false untuk NULL.
+func between(value *time.Time, from, to time.Time) bool {
+	return value != nil && !value.Before(from) && !value.After(to)
+}
+
+// copyTask menyalin field pointer dan slice supaya data di store tidak ikut berubah lewat hasil query.
+func copyTask(task domain.Task) domain.Task {
+	task.Deadline = copyTime(task.Deadline)
+	task.CompletedAt = copyTime(task.CompletedAt)
+
+	if task.Labels != nil {
+		task.Labels = append([]string{}, task.Labels...)
+	}
+
+	return task
+}
+
+func copyTime(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	copied := *value
+	return &copied
+}
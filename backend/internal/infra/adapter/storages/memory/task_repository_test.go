@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"errors"
+	"sync"
+	"task-management/internal/domain"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func TestTaskRepositoryGetByIDNotFound(t *testing.T) {
+	repo := NewTaskRepository()
+
+	if _, err := repo.GetByID(42); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("GetByID error = %v, want gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestTaskRepositoryCreateSetsDefaults(t *testing.T) {
+	repo := NewTaskRepository()
+
+	task := &domain.Task{UserID: 1, Title: "a"}
+	if err := repo.Create(task); err != nil {
+		t.Fatal(err)
+	}
+
+	if task.ID != 1 || task.Status != domain.ToDo || task.CreatedAt.IsZero() {
+		t.Fatalf("created task = %+v, want ID 1, status To Do and CreatedAt", task)
+	}
+
+	batch := []domain.Task{{UserID: 1, Title: "b"}, {UserID: 1, Title: "c"}}
+	if err := repo.CreateBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	if batch[0].ID != 2 || batch[1].ID != 3 {
+		t.Fatalf("batch IDs = %d, %d, want 2, 3", batch[0].ID, batch[1].ID)
+	}
+}
+
+func TestTaskRepositoryGetByUserOrdering(t *testing.T) {
+	repo := NewTaskRepository()
+	base := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	late, early := base.Add(48*time.Hour), base.Add(24*time.Hour)
+
+	tasks := []*domain.Task{
+		{UserID: 1, Title: "first", Deadline: &late, CreatedAt: base},
+		{UserID: 1, Title: "second", Deadline: &early, CreatedAt: base.Add(time.Minute)},
+		{UserID: 1, Title: "no deadline", CreatedAt: base.Add(2 * time.Minute)},
+		{UserID: 2, Title: "other user", Deadline: &early, CreatedAt: base},
+	}
+
+	for _, task := range tasks {
+		if err := repo.Create(task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all, _ := repo.GetByUser(1, nil, nil)
+	assertTitles(t, all, "first", "second", "no deadline")
+
+	dueBefore := base.Add(72 * time.Hour)
+	due, _ := repo.GetByUser(1, nil, &dueBefore)
+	assertTitles(t, due, "second", "first")
+
+	// dueBefore eksklusif seperti deadline < ? di SQL
+	due, _ = repo.GetByUser(1, nil, &early)
+	assertTitles(t, due)
+
+	done := domain.Done
+	none, _ := repo.GetByUser(1, &done, nil)
+	assertTitles(t, none)
+}
+
+func TestTaskRepositoryBetweenIsInclusive(t *testing.T) {
+	repo := NewTaskRepository()
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	_ = repo.Create(&domain.Task{UserID: 1, Title: "at from", Deadline: &from})
+	_ = repo.Create(&domain.Task{UserID: 1, Title: "at to", Deadline: &to})
+	_ = repo.Create(&domain.Task{UserID: 1, Title: "done", Deadline: &from, Status: domain.Done})
+
+	due, _ := repo.GetDueBetween(from, to)
+	assertTitles(t, due, "at from", "at to")
+}
+
+func TestTaskRepositoryReturnsCopies(t *testing.T) {
+	repo := NewTaskRepository()
+
+	task := &domain.Task{UserID: 1, Title: "a", Labels: []string{"x"}}
+	_ = repo.Create(task)
+	task.Labels[0] = "changed by caller"
+
+	got, _ := repo.GetByID(task.ID)
+	got.Labels[0] = "changed again"
+
+	stored, _ := repo.GetByID(task.ID)
+	if stored.Labels[0] != "x" {
+		t.Fatalf("stored labels = %v, want [x]", stored.Labels)
+	}
+}
+
+func TestTaskRepositoryCounts(t *testing.T) {
+	repo := NewTaskRepository()
+
+	_ = repo.Create(&domain.Task{UserID: 1, Title: "a"})
+	_ = repo.Create(&domain.Task{UserID: 1, Title: "b", Status: domain.Done})
+	_ = repo.Create(&domain.Task{UserID: 2, Title: "c"})
+
+	byUser, _ := repo.CountByUsers([]uint{1, 3})
+	if byUser[1] != 2 || len(byUser) != 1 {
+		t.Fatalf("CountByUsers = %v, want map[1:2]", byUser)
+	}
+
+	byStatus, _ := repo.CountByStatus(1)
+	if byStatus[domain.ToDo] != 1 || byStatus[domain.Done] != 1 {
+		t.Fatalf("CountByStatus = %v", byStatus)
+	}
+}
+
+func TestTaskRepositoryConcurrentCreate(t *testing.T) {
+	repo := NewTaskRepository()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = repo.Create(&domain.Task{UserID: 1, Title: "t"})
+		}()
+	}
+	wg.Wait()
+
+	tasks, _ := repo.GetByUser(1, nil, nil)
+	if len(tasks) != 50 {
+		t.Fatalf("stored %d tasks, want 50", len(tasks))
+	}
+}
+
+func assertTitles(t *testing.T, tasks []domain.Task, want ...string) {
+	t.Helper()
+
+	if len(tasks) != len(want) {
+		t.Fatalf("got %d tasks %v, want %v", len(tasks), tasks, want)
+	}
+
+	for i, task := range tasks {
+		if task.Title != want[i] {
+			t.Fatalf("task %d = %q, want %q", i, task.Title, want[i])
+		}
+	}
+}
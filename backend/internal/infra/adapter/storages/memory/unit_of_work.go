@@ -0,0 +1,211 @@
+package memory
+
+import (
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+)
+
+type unitOfWork struct {
+	mu    sync.Mutex
+	tasks *taskRepository
+	users *userRepository
+	inner repository.UnitOfWork
+}
+
+// NewUnitOfWork untuk repository memory. tasks dan users harus dibuat oleh package ini, repository
+// lain (notifikasi, link import) diambil dari transaksi inner. Unit of work dijalankan satu per satu
+// sehingga cek-lalu-tulis di dalamnya tidak bertabrakan. Jika fn atau commit inner gagal, hanya
+// perubahan yang dibuat lewat repos di dalam fn yang dibatalkan, tulisan dari luar unit of work tetap.
+// Saat user dihapus atau dianonimkan, data miliknya di database (session, token, notifikasi, dll.)
+// dihapus lewat repository user dari transaksi inner, sama seperti store gorm.
+func NewUnitOfWork(tasks repository.TaskRepository, users repository.UserRepository, inner repository.UnitOfWork) repository.UnitOfWork {
+	return &unitOfWork{tasks: tasks.(*taskRepository), users: users.(*userRepository), inner: inner}
+}
+
+// Do implements repository.UnitOfWork.
+func (u *unitOfWork) Do(fn func(repos repository.Repositories) error) (err error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var undo undoLog
+
+	defer func() {
+		if r := recover(); r != nil {
+			undo.rollback()
+			panic(r)
+		}
+
+		if err != nil {
+			undo.rollback()
+		}
+	}()
+
+	tasks := &journaledTasks{TaskRepository: u.tasks, repo: u.tasks, undo: &undo}
+
+	return u.inner.Do(func(repos repository.Repositories) error {
+		repos.Tasks = tasks
+		repos.Users = &journaledUsers{UserRepository: u.users, repo: u.users, store: repos.Users, tasks: tasks, undo: &undo}
+
+		return fn(repos)
+	})
+}
+
+// undoLog berisi langkah pemulihan untuk setiap tulisan di dalam unit of work.
+type undoLog []func()
+
+func (l *undoLog) add(step func()) {
+	*l = append(*l, step)
+}
+
+// rollback menjalankan langkah pemulihan dari tulisan terakhir ke yang pertama.
+func (l undoLog) rollback() {
+	for i := len(l) - 1; i >= 0; i-- {
+		l[i]()
+	}
+}
+
+// journaledTasks mencatat isi task sebelum setiap tulisan supaya bisa dikembalikan.
+type journaledTasks struct {
+	repository.TaskRepository
+	repo *taskRepository
+	undo *undoLog
+}
+
+// Create implements repository.TaskRepository.
+func (j *journaledTasks) Create(task *domain.Task) error {
+	if err := j.repo.Create(task); err != nil {
+		return err
+	}
+
+	j.record(task.ID, nil)
+	return nil
+}
+
+// CreateBatch implements repository.TaskRepository.
+func (j *journaledTasks) CreateBatch(tasks []domain.Task) error {
+	if err := j.repo.CreateBatch(tasks); err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		j.record(tasks[i].ID, nil)
+	}
+
+	return nil
+}
+
+// Update implements repository.TaskRepository.
+func (j *journaledTasks) Update(task *domain.Task) error {
+	previous := j.repo.lookup(task.ID)
+
+	if err := j.repo.Update(task); err != nil {
+		return err
+	}
+
+	j.record(task.ID, previous)
+	return nil
+}
+
+// Delete implements repository.TaskRepository.
+func (j *journaledTasks) Delete(id uint) error {
+	previous := j.repo.lookup(id)
+
+	if err := j.repo.Delete(id); err != nil {
+		return err
+	}
+
+	j.record(id, previous)
+	return nil
+}
+
+func (j *journaledTasks) record(id uint, previous *domain.Task) {
+	j.undo.add(func() { j.repo.restore(id, previous) })
+}
+
+// journaledUsers mencatat isi user sebelum setiap tulisan supaya bisa dikembalikan.
+// store adalah repository user dari transaksi inner, dipakai untuk data user di database.
+type journaledUsers struct {
+	repository.UserRepository
+	repo  *userRepository
+	store repository.UserRepository
+	tasks *journaledTasks
+	undo  *undoLog
+}
+
+// Create implements repository.UserRepository.
+func (j *journaledUsers) Create(user *domain.User) error {
+	if err := j.repo.Create(user); err != nil {
+		return err
+	}
+
+	j.record(user.ID, nil)
+	return nil
+}
+
+// Update implements repository.UserRepository.
+func (j *journaledUsers) Update(user *domain.User) error {
+	previous := j.repo.lookup(user.ID)
+
+	if err := j.repo.Update(user); err != nil {
+		return err
+	}
+
+	j.record(user.ID, previous)
+	return nil
+}
+
+// Anonymize implements repository.UserRepository.
+// User tidak ada di tabel database, jadi store hanya menghapus data miliknya.
+func (j *journaledUsers) Anonymize(user *domain.User) error {
+	if err := j.store.Anonymize(user); err != nil {
+		return err
+	}
+
+	return j.Update(user)
+}
+
+// UpdateTOTPStep implements repository.UserRepository.
+func (j *journaledUsers) UpdateTOTPStep(userID uint, step int64) (bool, error) {
+	previous := j.repo.lookup(userID)
+
+	updated, err := j.repo.UpdateTOTPStep(userID, step)
+	if err != nil || !updated {
+		return updated, err
+	}
+
+	j.record(userID, previous)
+	return true, nil
+}
+
+// Delete implements repository.UserRepository.
+// Task milik user dihapus lewat journaledTasks supaya ikut kembali saat rollback.
+func (j *journaledUsers) Delete(id uint) error {
+	if err := j.store.Delete(id); err != nil {
+		return err
+	}
+
+	tasks, err := j.tasks.GetByUser(id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if err := j.tasks.Delete(task.ID); err != nil {
+			return err
+		}
+	}
+
+	previous := j.repo.lookup(id)
+
+	if err := j.repo.Delete(id); err != nil {
+		return err
+	}
+
+	j.record(id, previous)
+	return nil
+}
+
+func (j *journaledUsers) record(id uint, previous *domain.User) {
+	j.undo.add(func() { j.repo.restore(id, previous) })
+}
@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/db"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// newDBUnitOfWork memakai transaksi SQLite asli sebagai inner, seperti di server.
+func newDBUnitOfWork(t *testing.T) (*gorm.DB, repository.UserRepository, repository.UnitOfWork) {
+	t.Helper()
+
+	database, err := db.Connect(config.DatabaseConfig{Driver: "sqlite", Name: ":memory:"})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = database.Close() })
+
+	migrator, err := db.NewMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	tasks, users, uow := newTestUnitOfWork(storages.NewUnitOfWork(database.DB))
+	_ = tasks.Create(&domain.Task{UserID: 1, Title: "owned"})
+
+	return database.DB, users, uow
+}
+
+// seedPersonalData menyimpan data milik user 1 di database dan satu session milik user lain.
+func seedPersonalData(t *testing.T, database *gorm.DB) {
+	t.Helper()
+
+	expires := time.Now().Add(time.Hour)
+	rows := []any{
+		&domain.Session{ID: "alice", UserID: 1, ExpiresAt: expires},
+		&domain.Session{ID: "bob", UserID: 2, ExpiresAt: expires},
+		&domain.APIToken{UserID: 1, Name: "cli", Prefix: "tmp_a", TokenHash: "hash"},
+		&domain.Notification{UserID: 1, Type: domain.NotificationTaskAssigned, Message: "hello"},
+		&domain.ExternalTaskLink{ImportedBy: 1, Source: "trello", ExternalID: "card", TaskID: 1},
+	}
+
+	for _, row := range rows {
+		if err := database.Create(row).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func count(t *testing.T, database *gorm.DB, model any, where string, args ...any) int64 {
+	t.Helper()
+
+	var total int64
+	if err := database.Model(model).Where(where, args...).Count(&total).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return total
+}
+
+func TestUnitOfWorkDeleteRemovesStoredData(t *testing.T) {
+	database, users, uow := newDBUnitOfWork(t)
+	_ = users.Create(&domain.User{Username: "alice"})
+	seedPersonalData(t, database)
+
+	err := uow.Do(func(repos repository.Repositories) error {
+		return repos.Users.Delete(1)
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if alice, _ := users.FindByID(1); alice != nil {
+		t.Error("user was kept")
+	}
+
+	for name, model := range map[string]any{
+		"sessions":      &domain.Session{},
+		"api tokens":    &domain.APIToken{},
+		"notifications": &domain.Notification{},
+	} {
+		if n := count(t, database, model, "user_id = ?", 1); n != 0 {
+			t.Errorf("%d %s left for the deleted user", n, name)
+		}
+	}
+
+	if n := count(t, database, &domain.ExternalTaskLink{}, "imported_by = ?", 1); n != 0 {
+		t.Errorf("%d import links left for the deleted user", n)
+	}
+
+	if n := count(t, database, &domain.Session{}, "user_id = ?", 2); n != 1 {
+		t.Errorf("sessions of another user = %d, want 1", n)
+	}
+}
+
+func TestUnitOfWorkAnonymizeRemovesStoredData(t *testing.T) {
+	database, users, uow := newDBUnitOfWork(t)
+	_ = users.Create(&domain.User{Username: "alice"})
+	seedPersonalData(t, database)
+
+	err := uow.Do(func(repos repository.Repositories) error {
+		return repos.Users.Anonymize(&domain.User{ID: 1, Username: "deleted-1"})
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if user, err := users.FindByID(1); err != nil || user.Username != "deleted-1" {
+		t.Errorf("user after anonymize = %v, %v", user, err)
+	}
+
+	if n := count(t, database, &domain.Session{}, "user_id = ?", 1); n != 0 {
+		t.Errorf("%d sessions left for the anonymized user", n)
+	}
+
+	// task dan link import tetap ada, user memory tidak ditulis ke tabel users
+	if n := count(t, database, &domain.ExternalTaskLink{}, "imported_by = ?", 1); n != 1 {
+		t.Errorf("import links = %d, want 1", n)
+	}
+
+	if n := count(t, database, &domain.User{}, "1 = 1"); n != 0 {
+		t.Errorf("users table has %d rows, want 0", n)
+	}
+}
+
+func TestUnitOfWorkRollbackRestoresStoredData(t *testing.T) {
+	database, users, uow := newDBUnitOfWork(t)
+	_ = users.Create(&domain.User{Username: "alice"})
+	seedPersonalData(t, database)
+
+	err := uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Users.Delete(1); err != nil {
+			return err
+		}
+
+		return errors.New("failed")
+	})
+	if err == nil {
+		t.Fatal("Do succeeded, want error")
+	}
+
+	if alice, err := users.FindByID(1); err != nil || alice.Username != "alice" {
+		t.Errorf("user after rollback = %v, %v", alice, err)
+	}
+
+	if n := count(t, database, &domain.Session{}, "user_id = ?", 1); n != 1 {
+		t.Errorf("sessions after rollback = %d, want 1", n)
+	}
+
+	if n := count(t, database, &domain.APIToken{}, "user_id = ?", 1); n != 1 {
+		t.Errorf("api tokens after rollback = %d, want 1", n)
+	}
+}
@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"testing"
+)
+
+// passthrough menggantikan transaksi database di test.
+type passthrough struct {
+	err error
+}
+
+// noStoredData menggantikan repository user database yang tidak menyimpan data apa pun.
+type noStoredData struct {
+	repository.UserRepository
+}
+
+func (noStoredData) Delete(id uint) error { return nil }
+
+func (noStoredData) Anonymize(user *domain.User) error { return nil }
+
+func (p passthrough) Do(fn func(repos repository.Repositories) error) error {
+	if err := fn(repository.Repositories{Users: noStoredData{}}); err != nil {
+		return err
+	}
+
+	return p.err
+}
+
+func newTestUnitOfWork(inner repository.UnitOfWork) (repository.TaskRepository, repository.UserRepository, repository.UnitOfWork) {
+	tasks := NewTaskRepository()
+	users := NewUserRepository(tasks)
+
+	return tasks, users, NewUnitOfWork(tasks, users, inner)
+}
+
+func TestUnitOfWorkRollsBackOnError(t *testing.T) {
+	tasks, users, uow := newTestUnitOfWork(passthrough{})
+
+	_ = users.Create(&domain.User{Username: "alice"})
+	_ = tasks.Create(&domain.Task{UserID: 1, Title: "keep"})
+
+	failed := errors.New("failed")
+	err := uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Users.Create(&domain.User{Username: "bob"}); err != nil {
+			return err
+		}
+
+		if err := repos.Users.Delete(1); err != nil {
+			return err
+		}
+
+		return failed
+	})
+
+	if !errors.Is(err, failed) {
+		t.Fatalf("Do error = %v, want %v", err, failed)
+	}
+
+	if bob, _ := users.FindByUsername("bob"); bob != nil {
+		t.Error("user created in failed unit of work was kept")
+	}
+
+	if alice, _ := users.FindByID(1); alice == nil {
+		t.Error("user deleted in failed unit of work was not restored")
+	}
+
+	if task, err := tasks.GetByID(1); err != nil || task.Title != "keep" {
+		t.Errorf("task after rollback = %v, %v", task, err)
+	}
+
+	// ID yang sudah dibagikan di unit of work yang gagal tidak dipakai lagi
+	carol := &domain.User{Username: "carol"}
+	if err := users.Create(carol); err != nil || carol.ID != 3 {
+		t.Errorf("next user ID = %d, %v, want 3", carol.ID, err)
+	}
+}
+
+func TestUnitOfWorkRollbackRestoresDeletedUserTasks(t *testing.T) {
+	tasks, users, uow := newTestUnitOfWork(passthrough{})
+
+	_ = users.Create(&domain.User{Username: "alice"})
+	_ = tasks.Create(&domain.Task{UserID: 1, Title: "owned"})
+
+	_ = uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Users.Delete(1); err != nil {
+			return err
+		}
+
+		return errors.New("failed")
+	})
+
+	if task, err := tasks.GetByID(1); err != nil || task.Title != "owned" {
+		t.Errorf("task of deleted user after rollback = %v, %v", task, err)
+	}
+}
+
+// tulisan dari luar unit of work selama fn berjalan tidak ikut dibatalkan
+func TestUnitOfWorkRollbackKeepsOutsideWrites(t *testing.T) {
+	tasks, _, uow := newTestUnitOfWork(passthrough{})
+
+	_ = tasks.Create(&domain.Task{UserID: 1, Title: "existing"})
+
+	started := make(chan struct{})
+	written := make(chan *domain.Task)
+
+	go func() {
+		<-started
+
+		task := &domain.Task{UserID: 2, Title: "outside"}
+		_ = tasks.Create(task)
+
+		outside, _ := tasks.GetByID(1)
+		outside.Title = "renamed outside"
+		_ = tasks.Update(outside)
+
+		written <- task
+	}()
+
+	var outside *domain.Task
+	err := uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Tasks.Create(&domain.Task{UserID: 1, Title: "inside"}); err != nil {
+			return err
+		}
+
+		close(started)
+		outside = <-written
+
+		return errors.New("task not found")
+	})
+
+	if err == nil {
+		t.Fatal("Do succeeded, want error")
+	}
+
+	if task, err := tasks.GetByID(outside.ID); err != nil || task.Title != "outside" {
+		t.Errorf("task created outside the unit of work = %v, %v", task, err)
+	}
+
+	if task, err := tasks.GetByID(1); err != nil || task.Title != "renamed outside" {
+		t.Errorf("task updated outside the unit of work = %v, %v", task, err)
+	}
+
+	if _, err := tasks.GetByID(2); err == nil {
+		t.Error("task created inside the failed unit of work was kept")
+	}
+
+	next := &domain.Task{UserID: 1, Title: "next"}
+	if err := tasks.Create(next); err != nil || next.ID == outside.ID || next.ID == 2 {
+		t.Errorf("next task ID = %d, %v, reuses an ID already handed out", next.ID, err)
+	}
+}
+
+func TestUnitOfWorkRollsBackWhenCommitFails(t *testing.T) {
+	failed := errors.New("commit failed")
+	_, users, uow := newTestUnitOfWork(passthrough{err: failed})
+
+	err := uow.Do(func(repos repository.Repositories) error {
+		return repos.Users.Create(&domain.User{Username: "alice"})
+	})
+
+	if !errors.Is(err, failed) {
+		t.Fatalf("Do error = %v, want %v", err, failed)
+	}
+
+	if alice, _ := users.FindByUsername("alice"); alice != nil {
+		t.Error("user was kept after the inner transaction failed")
+	}
+}
+
+func TestUnitOfWorkRollsBackOnPanic(t *testing.T) {
+	_, users, uow := newTestUnitOfWork(passthrough{})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("panic was not propagated")
+			}
+		}()
+
+		_ = uow.Do(func(repos repository.Repositories) error {
+			_ = repos.Users.Create(&domain.User{Username: "alice"})
+			panic("boom")
+		})
+	}()
+
+	if alice, _ := users.FindByUsername("alice"); alice != nil {
+		t.Error("user was kept after panic")
+	}
+}
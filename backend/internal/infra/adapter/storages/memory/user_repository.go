@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type userRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]domain.User
+	nextID uint
+	tasks  repository.TaskRepository
+}
+
+// NewUserRepository menyimpan user di memory proses. tasks dipakai untuk menghapus task milik user
+// saat user dihapus. Data lain milik user (notifikasi, session, token) ada di database dan hanya ikut
+// dihapus jika user dihapus lewat NewUnitOfWork. Karena ID user dimulai dari 1 lagi setelah restart,
+// server hanya mengizinkan store memory dengan SQLite ":memory:".
+func NewUserRepository(tasks repository.TaskRepository) repository.UserRepository {
+	return &userRepository{users: map[uint]domain.User{}, nextID: 1, tasks: tasks}
+}
+
+// Create implements repository.UserRepository.
+// Username yang sudah dipakai ditolak dengan gorm.ErrDuplicatedKey seperti unique index di database.
+func (u *userRepository) Create(user *domain.User) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, exists := u.users[user.ID]; exists || u.usernameTaken(user.Username, user.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	u.insert(user)
+	return nil
+}
+
+// insert mengisi ID, CreatedAt dan role default seperti insert gorm, mu harus sudah di-lock.
+func (u *userRepository) insert(user *domain.User) {
+	if user.ID == 0 {
+		user.ID = u.nextID
+	}
+
+	if user.ID >= u.nextID {
+		u.nextID = user.ID + 1
+	}
+
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now().UTC()
+	}
+
+	if user.Role == "" {
+		user.Role = domain.RoleUser
+	}
+
+	u.users[user.ID] = copyUser(*user)
+}
+
+// FindByID implements repository.UserRepository.
+func (u *userRepository) FindByID(id uint) (*domain.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, ok := u.users[id]
+	if !ok {
+		return nil, nil
+	}
+
+	user = copyUser(user)
+	return &user, nil
+}
+
+// FindByUsername implements repository.UserRepository.
+// Username dibandingkan tanpa membedakan huruf besar kecil seperti collation default MySQL.
+func (u *userRepository) FindByUsername(username string) (*domain.User, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	for _, user := range u.users {
+		if strings.EqualFold(user.Username, username) {
+			user = copyUser(user)
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Update implements repository.UserRepository.
+// Sama seperti Save di gorm, user tanpa ID atau yang belum ada akan di-insert.
+func (u *userRepository) Update(user *domain.User) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.usernameTaken(user.Username, user.ID) {
+		return gorm.ErrDuplicatedKey
+	}
+
+	if _, ok := u.users[user.ID]; !ok {
+		u.insert(user)
+		return nil
+	}
+
+	u.users[user.ID] = copyUser(*user)
+	return nil
+}
+
+// CountByRole implements repository.UserRepository.
+func (u *userRepository) CountByRole(role domain.Role) (int64, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var total int64
+	for _, user := range u.users {
+		if user.Role == role {
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+// LockAdmins implements repository.UserRepository.
+// Unit of work memory sudah berjalan satu per satu sehingga tidak perlu kunci tambahan.
+func (u *userRepository) LockAdmins() error {
+	return nil
+}
+
+// UpdateTOTPStep implements repository.UserRepository.
+func (u *userRepository) UpdateTOTPStep(userID uint, step int64) (bool, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	user, ok := u.users[userID]
+	if !ok || !user.TOTPEnabled || user.TOTPLastStep >= step {
+		return false, nil
+	}
+
+	user.TOTPLastStep = step
+	u.users[userID] = user
+	return true, nil
+}
+
+// Search implements repository.UserRepository.
+func (u *userRepository) Search(query string, limit, offset int) ([]domain.User, int64, error) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	query = strings.ToLower(query)
+
+	var users []domain.User
+	for _, user := range u.users {
+		if query == "" ||
+			strings.Contains(strings.ToLower(user.Username), query) ||
+			strings.Contains(strings.ToLower(user.Name), query) ||
+			strings.Contains(strings.ToLower(user.Email), query) {
+			users = append(users, copyUser(user))
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+
+	total := int64(len(users))
+
+	if offset > 0 {
+		users = users[min(offset, len(users)):]
+	}
+
+	if limit > 0 && limit < len(users) {
+		users = users[:limit]
+	}
+
+	return users, total, nil
+}
+
+// Delete implements repository.UserRepository.
+func (u *userRepository) Delete(id uint) error {
+	// lock dipegang selama task dihapus supaya user tidak terlihat tanpa sebagian task-nya
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	tasks, err := u.tasks.GetByUser(id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if err := u.tasks.Delete(task.ID); err != nil {
+			return err
+		}
+	}
+
+	delete(u.users, id)
+	return nil
+}
+
+// Anonymize implements repository.UserRepository.
+func (u *userRepository) Anonymize(user *domain.User) error {
+	return u.Update(user)
+}
+
+// lookup mengembalikan salinan user dengan id, nil jika tidak ada.
+func (u *userRepository) lookup(id uint) *domain.User {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	user, ok := u.users[id]
+	if !ok {
+		return nil
+	}
+
+	user = copyUser(user)
+	return &user
+}
+
+// restore mengembalikan user dengan id ke previous, previous nil berarti user dihapus.
+// nextID tidak dikembalikan supaya ID yang sudah dibagikan tidak dipakai lagi, sama seperti auto increment.
+func (u *userRepository) restore(id uint, previous *domain.User) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if previous == nil {
+		delete(u.users, id)
+		return
+	}
+
+	u.users[id] = copyUser(*previous)
+}
+
+// usernameTaken mengecek unique username tanpa membedakan huruf besar kecil, mu harus sudah di-lock.
+func (u *userRepository) usernameTaken(username string, exceptID uint) bool {
+	for _, user := range u.users {
+		if strings.EqualFold(user.Username, username) && user.ID != exceptID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func copyUser(user domain.User) domain.User {
+	user.EmailVerifiedAt = copyTime(user.EmailVerifiedAt)
+	user.AvatarUpdatedAt = copyTime(user.AvatarUpdatedAt)
+	user.DeactivatedAt = copyTime(user.DeactivatedAt)
+	user.PasswordChangedAt = copyTime(user.PasswordChangedAt)
+
+	return user
+}
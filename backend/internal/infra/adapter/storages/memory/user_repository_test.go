@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"errors"
+	"task-management/internal/domain"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestUserRepositoryUniqueUsername(t *testing.T) {
+	repo := NewUserRepository(NewTaskRepository())
+
+	alice := &domain.User{Username: "alice", Name: "Alice"}
+	if err := repo.Create(alice); err != nil {
+		t.Fatal(err)
+	}
+
+	if alice.ID != 1 || alice.Role != domain.RoleUser {
+		t.Fatalf("created user = %+v, want ID 1 and role user", alice)
+	}
+
+	if err := repo.Create(&domain.User{Username: "ALICE"}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("Create duplicate error = %v, want gorm.ErrDuplicatedKey", err)
+	}
+
+	bob := &domain.User{Username: "bob"}
+	_ = repo.Create(bob)
+
+	bob.Username = "Alice"
+	if err := repo.Update(bob); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("Update duplicate error = %v, want gorm.ErrDuplicatedKey", err)
+	}
+}
+
+func TestUserRepositoryFind(t *testing.T) {
+	repo := NewUserRepository(NewTaskRepository())
+	_ = repo.Create(&domain.User{Username: "Alice"})
+
+	user, err := repo.FindByUsername("alice")
+	if err != nil || user == nil || user.Username != "Alice" {
+		t.Fatalf("FindByUsername = %v, %v", user, err)
+	}
+
+	missing, err := repo.FindByID(99)
+	if missing != nil || err != nil {
+		t.Fatalf("FindByID missing = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestUserRepositorySearch(t *testing.T) {
+	repo := NewUserRepository(NewTaskRepository())
+	_ = repo.Create(&domain.User{Username: "alice", Name: "Alice Smith"})
+	_ = repo.Create(&domain.User{Username: "bob", Email: "bob@SMITH.dev"})
+	_ = repo.Create(&domain.User{Username: "carol"})
+
+	users, total, _ := repo.Search("smith", 1, 1)
+	if total != 2 || len(users) != 1 || users[0].Username != "bob" {
+		t.Fatalf("Search = %v, %d", users, total)
+	}
+
+	_, total, _ = repo.Search("", 10, 0)
+	if total != 3 {
+		t.Fatalf("Search all total = %d, want 3", total)
+	}
+}
+
+func TestUserRepositoryDeleteRemovesTasks(t *testing.T) {
+	tasks := NewTaskRepository()
+	repo := NewUserRepository(tasks)
+
+	user := &domain.User{Username: "alice"}
+	_ = repo.Create(user)
+	_ = tasks.Create(&domain.Task{UserID: user.ID, Title: "a"})
+	_ = tasks.Create(&domain.Task{UserID: user.ID + 1, Title: "other"})
+
+	if err := repo.Delete(user.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if found, _ := repo.FindByID(user.ID); found != nil {
+		t.Fatal("user still exists")
+	}
+
+	left, _ := tasks.GetByUser(user.ID, nil, nil)
+	other, _ := tasks.GetByUser(user.ID+1, nil, nil)
+	if len(left) != 0 || len(other) != 1 {
+		t.Fatalf("tasks after delete = %d own, %d other", len(left), len(other))
+	}
+}
+
+func TestUserRepositoryCountByRole(t *testing.T) {
+	repo := NewUserRepository(NewTaskRepository())
+	_ = repo.Create(&domain.User{Username: "a", Role: domain.RoleAdmin})
+	_ = repo.Create(&domain.User{Username: "b"})
+
+	admins, _ := repo.CountByRole(domain.RoleAdmin)
+	if admins != 1 {
+		t.Fatalf("CountByRole admin = %d, want 1", admins)
+	}
+}
@@ -0,0 +1,47 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type notificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) repository.NotificationPreferenceRepository {
+	return &notificationPreferenceRepository{db: db}
+}
+
+// FindByUser implements repository.NotificationPreferenceRepository.
+func (n *notificationPreferenceRepository) FindByUser(userID uint) (*domain.NotificationPreference, error) {
+	var preference domain.NotificationPreference
+	if err := n.db.Where("user_id = ?", userID).First(&preference).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &preference, nil
+}
+
+// FindByDigest implements repository.NotificationPreferenceRepository.
+func (n *notificationPreferenceRepository) FindByDigest(frequency domain.DigestFrequency) ([]domain.NotificationPreference, error) {
+	var preferences []domain.NotificationPreference
+
+	err := n.db.Where("digest = ? AND email_enabled = ?", frequency, true).
+		Order("user_id ASC").
+		Find(&preferences).Error
+
+	return preferences, err
+}
+
+// Save implements repository.NotificationPreferenceRepository.
+func (n *notificationPreferenceRepository) Save(preference *domain.NotificationPreference) error {
+	return n.db.Save(preference).Error
+}
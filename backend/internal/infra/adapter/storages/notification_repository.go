@@ -0,0 +1,81 @@
+package storages
+
+import (
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) repository.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create implements repository.NotificationRepository.
+func (n *notificationRepository) Create(notification *domain.Notification) error {
+	return n.db.Create(notification).Error
+}
+
+// GetByID implements repository.NotificationRepository.
+func (n *notificationRepository) GetByID(id uint) (*domain.Notification, error) {
+	var notification domain.Notification
+
+	if err := n.db.First(&notification, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// GetByUser implements repository.NotificationRepository.
+func (n *notificationRepository) GetByUser(userID uint, unreadOnly bool, limit, offset int) ([]domain.Notification, int64, error) {
+	var notifications []domain.Notification
+	var total int64
+
+	query := n.db.Model(&domain.Notification{}).Where("user_id = ?", userID)
+
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Order("created_at DESC").Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+
+	return notifications, total, err
+}
+
+// Exists implements repository.NotificationRepository.
+func (n *notificationRepository) Exists(userID, taskID uint, notificationType domain.NotificationType) (bool, error) {
+	var count int64
+
+	err := n.db.Model(&domain.Notification{}).
+		Where("user_id = ? AND task_id = ? AND type = ?", userID, taskID, notificationType).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// MarkRead implements repository.NotificationRepository.
+func (n *notificationRepository) MarkRead(id uint) error {
+	return n.db.Model(&domain.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", time.Now()).Error
+}
+
+// MarkAllRead implements repository.NotificationRepository.
+func (n *notificationRepository) MarkAllRead(userID uint) error {
+	return n.db.Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
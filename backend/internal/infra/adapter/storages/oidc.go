@@ -0,0 +1,88 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type userIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) repository.UserIdentityRepository {
+	return &userIdentityRepository{db: db}
+}
+
+// Find implements repository.UserIdentityRepository.
+func (r *userIdentityRepository) Find(issuer string, subject string) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+	if err := r.db.Where("issuer = ? AND subject = ?", issuer, subject).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &identity, nil
+}
+
+// Create implements repository.UserIdentityRepository.
+func (r *userIdentityRepository) Create(identity *domain.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// ListByUser implements repository.UserIdentityRepository.
+func (r *userIdentityRepository) ListByUser(userID uint) ([]domain.UserIdentity, error) {
+	var identities []domain.UserIdentity
+
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+
+	return identities, err
+}
+
+type oidcLoginStateRepository struct {
+	db *gorm.DB
+}
+
+func NewOIDCLoginStateRepository(db *gorm.DB) repository.OIDCLoginStateRepository {
+	return &oidcLoginStateRepository{db: db}
+}
+
+// Create implements repository.OIDCLoginStateRepository.
+func (r *oidcLoginStateRepository) Create(state *domain.OIDCLoginState) error {
+	return r.db.Create(state).Error
+}
+
+// Take implements repository.OIDCLoginStateRepository.
+func (r *oidcLoginStateRepository) Take(state string) (*domain.OIDCLoginState, error) {
+	var loginState domain.OIDCLoginState
+	if err := r.db.Where("state = ?", state).First(&loginState).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	// jika request lain sudah menghapus state ini lebih dulu, anggap tidak ditemukan
+	result := r.db.Where("state = ?", state).Delete(&domain.OIDCLoginState{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &loginState, nil
+}
+
+// DeleteExpired implements repository.OIDCLoginStateRepository.
+func (r *oidcLoginStateRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&domain.OIDCLoginState{}).Error
+}
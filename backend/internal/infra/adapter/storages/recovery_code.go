@@ -0,0 +1,62 @@
+package storages
+
+import (
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) repository.RecoveryCodeRepository {
+	return &recoveryCodeRepository{db: db}
+}
+
+// Replace implements repository.RecoveryCodeRepository.
+func (r *recoveryCodeRepository) Replace(userID uint, codes []domain.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		if len(codes) == 0 {
+			return nil
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+// Use implements repository.RecoveryCodeRepository.
+func (r *recoveryCodeRepository) Use(userID uint, codeHash string, at time.Time) (bool, error) {
+	// update bersyarat used_at IS NULL supaya kode yang sama tidak bisa dipakai dua kali bersamaan
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// CountUnused implements repository.RecoveryCodeRepository.
+func (r *recoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+
+	return count, err
+}
+
+// DeleteByUser implements repository.RecoveryCodeRepository.
+func (r *recoveryCodeRepository) DeleteByUser(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+}
@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// takeScript mengisi ulang bucket berdasarkan waktu server Redis lalu mengambil satu token
+// secara atomik, sehingga semua instance berbagi limit yang sama.
+var takeScript = goredis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local data = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil or ts == nil then
+  tokens = limit
+  ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(limit, tokens + elapsed * limit / period)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], period)
+
+return {allowed, tostring(tokens)}
+`)
+
+type rateLimitStore struct {
+	client  goredis.UniversalClient
+	prefix  string
+	timeout time.Duration
+}
+
+// NewRateLimitStore menyimpan bucket di Redis dengan prefix key tertentu.
+func NewRateLimitStore(client goredis.UniversalClient, prefix string) repository.RateLimitStore {
+	return &rateLimitStore{
+		client:  client,
+		prefix:  prefix,
+		timeout: time.Second,
+	}
+}
+
+// Take implements repository.RateLimitStore.
+func (s *rateLimitStore) Take(key string, rule domain.RateLimit) (*domain.RateLimitResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	res, err := takeScript.Run(ctx, s.client, []string{s.prefix + key}, rule.Limit, rule.Period.Milliseconds()).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, _ := res[0].(int64)
+
+	tokens, err := strconv.ParseFloat(res[1].(string), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewRateLimitResult(rule, tokens, allowed == 1), nil
+}
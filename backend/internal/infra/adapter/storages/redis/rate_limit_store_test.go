@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"task-management/internal/domain"
+	"task-management/internal/utils"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// newTestStore terhubung ke Redis lokal (REDIS_ADDR, default localhost:6379) dan melewati test
+// jika Redis tidak bisa dihubungi. Setiap test memakai prefix sendiri yang dihapus setelah selesai.
+func newTestStore(t *testing.T) (*rateLimitStore, *goredis.Client) {
+	t.Helper()
+
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
+	client := goredis.NewClient(&goredis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("redis not reachable at %s: %v", addr, err)
+	}
+
+	suffix, err := utils.GenerateToken(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefix := "test:ratelimit:" + suffix + ":"
+	t.Cleanup(func() {
+		keys, _ := client.Keys(context.Background(), prefix+"*").Result()
+		if len(keys) > 0 {
+			client.Del(context.Background(), keys...)
+		}
+	})
+
+	return NewRateLimitStore(client, prefix).(*rateLimitStore), client
+}
+
+func TestRateLimitStoreBurst(t *testing.T) {
+	store, _ := newTestStore(t)
+	rule := domain.RateLimit{Limit: 3, Period: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		result, err := store.Take("ip:10.0.0.1", rule)
+		if err != nil || !result.Allowed || result.Remaining != 2-i {
+			t.Fatalf("request %d = %+v, %v, want allowed with %d remaining", i+1, result, err, 2-i)
+		}
+	}
+
+	result, err := store.Take("ip:10.0.0.1", rule)
+	if err != nil || result.Allowed || result.RetryAfter <= 0 {
+		t.Fatalf("request over the burst = %+v, %v, want denied with retry after", result, err)
+	}
+
+	// bucket lain tidak terpengaruh
+	if result, err := store.Take("ip:10.0.0.2", rule); err != nil || !result.Allowed {
+		t.Errorf("other key = %+v, %v, want allowed", result, err)
+	}
+}
+
+func TestRateLimitStoreRefill(t *testing.T) {
+	store, _ := newTestStore(t)
+	rule := domain.RateLimit{Limit: 5, Period: 500 * time.Millisecond}
+
+	for i := 0; i < 5; i++ {
+		if result, err := store.Take("refill", rule); err != nil || !result.Allowed {
+			t.Fatalf("request %d = %+v, %v", i+1, result, err)
+		}
+	}
+
+	if result, _ := store.Take("refill", rule); result.Allowed {
+		t.Fatal("empty bucket allowed a request")
+	}
+
+	// satu token terisi setiap 100ms
+	time.Sleep(150 * time.Millisecond)
+
+	if result, err := store.Take("refill", rule); err != nil || !result.Allowed {
+		t.Errorf("request after refill = %+v, %v, want allowed", result, err)
+	}
+}
+
+func TestRateLimitStoreExpiresIdleBuckets(t *testing.T) {
+	store, client := newTestStore(t)
+	rule := domain.RateLimit{Limit: 2, Period: 300 * time.Millisecond}
+
+	if _, err := store.Take("idle", rule); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	key := store.prefix + "idle"
+
+	ttl, err := client.PTTL(ctx, key).Result()
+	if err != nil || ttl <= 0 || ttl > rule.Period {
+		t.Fatalf("ttl = %s, %v, want at most the period", ttl, err)
+	}
+
+	time.Sleep(rule.Period + 100*time.Millisecond)
+
+	if exists, err := client.Exists(ctx, key).Result(); err != nil || exists != 0 {
+		t.Errorf("bucket still exists after the period: %d, %v", exists, err)
+	}
+}
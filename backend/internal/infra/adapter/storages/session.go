@@ -0,0 +1,85 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// Create implements repository.SessionRepository.
+func (r *sessionRepository) Create(session *domain.Session) error {
+	return r.db.Create(session).Error
+}
+
+// FindByID implements repository.SessionRepository.
+func (r *sessionRepository) FindByID(id string) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// ListActiveByUser implements repository.SessionRepository.
+func (r *sessionRepository) ListActiveByUser(userID uint, now time.Time) ([]domain.Session, error) {
+	var sessions []domain.Session
+
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+
+	return sessions, err
+}
+
+// ListByUser implements repository.SessionRepository.
+func (r *sessionRepository) ListByUser(userID uint) ([]domain.Session, error) {
+	var sessions []domain.Session
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&sessions).Error
+
+	return sessions, err
+}
+
+// Revoke implements repository.SessionRepository.
+func (r *sessionRepository) Revoke(id string, at time.Time) error {
+	return r.db.Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// RevokeAllByUser implements repository.SessionRepository.
+func (r *sessionRepository) RevokeAllByUser(userID uint, at time.Time) error {
+	return r.db.Model(&domain.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+// TouchLastSeen implements repository.SessionRepository.
+func (r *sessionRepository) TouchLastSeen(id string, at time.Time) error {
+	return r.db.Model(&domain.Session{}).
+		Where("id = ?", id).
+		Update("last_seen_at", at).Error
+}
+
+// DeleteExpired implements repository.SessionRepository.
+func (r *sessionRepository) DeleteExpired(before time.Time) error {
+	return r.db.Where("expires_at < ?", before).Delete(&domain.Session{}).Error
+}
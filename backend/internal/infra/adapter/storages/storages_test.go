@@ -0,0 +1,32 @@
+package storages
+
+import (
+	"task-management/internal/config"
+	"task-management/internal/infra/db"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// openTestDB membuat database SQLite in-memory dengan skema dari migrasi.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	database, err := db.Connect(config.DatabaseConfig{Driver: "sqlite", Name: ":memory:"})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = database.Close() })
+
+	migrator, err := db.NewMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	return database.DB
+}
@@ -18,9 +18,22 @@ func NewTaskRepository(db *gorm.DB) repository.TaskRepository {
 
 // Create implements repository.TaskRepository.
 func (t *taskRepository) Create(task *domain.Task) error {
+	toUTC(task)
 	return t.db.Create(task).Error
 }
 
+// CreateBatch implements repository.TaskRepository.
+// Semua task di-insert dalam satu transaksi, gagal satu berarti gagal semua.
+func (t *taskRepository) CreateBatch(tasks []domain.Task) error {
+	for i := range tasks {
+		toUTC(&tasks[i])
+	}
+
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&tasks).Error
+	})
+}
+
 // Delete implements repository.TaskRepository.
 func (t *taskRepository) Delete(id uint) error {
 	return t.db.Delete(&domain.Task{}, id).Error
@@ -38,25 +51,174 @@ func (t *taskRepository) GetByID(id uint) (*domain.Task, error) {
 }
 
 // GetByUser implements repository.TaskRepository.
-func (t *taskRepository) GetByUser(userID uint, status *domain.TaskStatus, deadline *time.Time) ([]domain.Task, error) {
+func (t *taskRepository) GetByUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time) ([]domain.Task, error) {
 	var tasks []domain.Task
+
+	err := t.userQuery(userID, status, dueBefore).Find(&tasks).Error
+	return tasks, err
+}
+
+// StreamByUser implements repository.TaskRepository.
+// Baris dibaca satu per satu dari cursor sehingga hasil besar tidak dimuat ke memory.
+func (t *taskRepository) StreamByUser(userID uint, status *domain.TaskStatus, dueBefore *time.Time, fn func(task *domain.Task) error) error {
+	query := t.userQuery(userID, status, dueBefore)
+
+	rows, err := query.Model(&domain.Task{}).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task domain.Task
+
+		if err := query.ScanRows(rows, &task); err != nil {
+			return err
+		}
+
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (t *taskRepository) userQuery(userID uint, status *domain.TaskStatus, dueBefore *time.Time) *gorm.DB {
 	query := t.db.Where("user_id = ?", userID)
 
 	if status != nil {
 		query = query.Where("status = ?", *status)
 	}
 
-	if deadline != nil {
-		query = query.Where("deadline <= ?", *deadline).Order("deadline ASC")
+	if dueBefore != nil {
+		query = query.Where("deadline < ?", dueBefore.UTC()).Order("deadline ASC")
 	} else {
 		query = query.Order("created_at ASC")
 	}
 
-	err := query.Find(&tasks).Error
+	return query
+}
+
+// GetDueBetween implements repository.TaskRepository.
+func (t *taskRepository) GetDueBetween(from, to time.Time) ([]domain.Task, error) {
+	var tasks []domain.Task
+
+	err := t.db.Where("deadline BETWEEN ? AND ?", from.UTC(), to.UTC()).
+		Where("status <> ?", domain.Done).
+		Order("deadline ASC").
+		Find(&tasks).Error
+
+	return tasks, err
+}
+
+// GetOverdueByUser implements repository.TaskRepository.
+func (t *taskRepository) GetOverdueByUser(userID uint, now time.Time) ([]domain.Task, error) {
+	var tasks []domain.Task
+
+	err := t.db.Where("user_id = ? AND deadline < ?", userID, now.UTC()).
+		Where("status <> ?", domain.Done).
+		Order("deadline ASC").
+		Find(&tasks).Error
+
+	return tasks, err
+}
+
+// GetDueBetweenByUser implements repository.TaskRepository.
+func (t *taskRepository) GetDueBetweenByUser(userID uint, from, to time.Time) ([]domain.Task, error) {
+	var tasks []domain.Task
+
+	err := t.db.Where("user_id = ? AND deadline BETWEEN ? AND ?", userID, from.UTC(), to.UTC()).
+		Where("status <> ?", domain.Done).
+		Order("deadline ASC").
+		Find(&tasks).Error
+
+	return tasks, err
+}
+
+// GetCompletedBetweenByUser implements repository.TaskRepository.
+func (t *taskRepository) GetCompletedBetweenByUser(userID uint, from, to time.Time) ([]domain.Task, error) {
+	var tasks []domain.Task
+
+	err := t.db.Where("user_id = ? AND status = ?", userID, domain.Done).
+		Where("completed_at BETWEEN ? AND ?", from.UTC(), to.UTC()).
+		Order("completed_at ASC").
+		Find(&tasks).Error
+
 	return tasks, err
 }
 
 // Update implements repository.TaskRepository.
 func (t *taskRepository) Update(task *domain.Task) error {
+	toUTC(task)
 	return t.db.Save(task).Error
 }
+
+// CountByUsers implements repository.TaskRepository.
+func (t *taskRepository) CountByUsers(userIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(userIDs))
+	if len(userIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		UserID uint
+		Total  int64
+	}
+
+	err := t.db.Model(&domain.Task{}).
+		Select("user_id, COUNT(*) AS total").
+		Where("user_id IN ?", userIDs).
+		Group("user_id").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.UserID] = row.Total
+	}
+
+	return counts, nil
+}
+
+// CountByStatus implements repository.TaskRepository.
+func (t *taskRepository) CountByStatus(userID uint) (map[domain.TaskStatus]int64, error) {
+	var rows []struct {
+		Status domain.TaskStatus
+		Total  int64
+	}
+
+	err := t.db.Model(&domain.Task{}).
+		Select("status, COUNT(*) AS total").
+		Where("user_id = ?", userID).
+		Group("status").
+		Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.TaskStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+
+	return counts, nil
+}
+
+// toUTC menyimpan waktu task dalam UTC. SQLite menyimpan waktu sebagai teks dengan offset,
+// jadi perbandingan deadline di query hanya benar jika nilai tersimpan dan parameter query
+// memakai zona yang sama.
+func toUTC(task *domain.Task) {
+	if task.Deadline != nil {
+		deadline := task.Deadline.UTC()
+		task.Deadline = &deadline
+	}
+
+	if task.CompletedAt != nil {
+		completedAt := task.CompletedAt.UTC()
+		task.CompletedAt = &completedAt
+	}
+}
@@ -0,0 +1,71 @@
+package storages
+
+import (
+	"task-management/internal/domain"
+	"testing"
+	"time"
+)
+
+// SQLite membandingkan waktu sebagai teks, jadi deadline dan batas query dengan offset
+// berbeda harus tetap dibandingkan sebagai waktu
+func TestTaskRepositoryDueBeforeIsExclusiveAcrossZones(t *testing.T) {
+	repo := NewTaskRepository(openTestDB(t))
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastSecond := time.Date(2026, 3, 1, 23, 59, 59, 0, jakarta)
+	midnight := time.Date(2026, 3, 2, 0, 0, 0, 0, jakarta)
+	earlyUTC := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2026, 3, 1, 8, 0, 0, 0, jakarta)
+
+	for _, task := range []domain.Task{
+		{UserID: 1, Title: "last second", Status: domain.Done, Deadline: &lastSecond, CompletedAt: &completedAt},
+		{UserID: 1, Title: "next midnight", Status: domain.ToDo, Deadline: &midnight},
+		{UserID: 1, Title: "next day in Jakarta", Status: domain.ToDo, Deadline: &earlyUTC},
+	} {
+		if err := repo.Create(&task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dueBefore := time.Date(2026, 3, 2, 0, 0, 0, 0, jakarta)
+
+	tasks, err := repo.GetByUser(1, nil, &dueBefore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tasks) != 1 || tasks[0].Title != "last second" {
+		t.Fatalf("tasks due before %v = %v, want only the last second of 1 March", dueBefore, tasks)
+	}
+
+	if !tasks[0].Deadline.Equal(lastSecond) {
+		t.Errorf("deadline = %v, want %v", tasks[0].Deadline, lastSecond)
+	}
+
+	var streamed []string
+	err = repo.StreamByUser(1, nil, &dueBefore, func(task *domain.Task) error {
+		streamed = append(streamed, task.Title)
+		return nil
+	})
+	if err != nil || len(streamed) != 1 {
+		t.Errorf("streamed = %v, %v, want the same single task", streamed, err)
+	}
+
+	// rentang digest juga dihitung dari hari lokal user
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, jakarta)
+	to := time.Date(2026, 3, 1, 23, 59, 59, 0, jakarta)
+
+	completed, err := repo.GetCompletedBetweenByUser(1, from, to)
+	if err != nil || len(completed) != 1 {
+		t.Errorf("completed on 1 March = %v, %v, want 1", completed, err)
+	}
+
+	due, err := repo.GetDueBetweenByUser(1, dueBefore, dueBefore.AddDate(0, 0, 1))
+	if err != nil || len(due) != 2 {
+		t.Errorf("due on 2 March = %v, %v, want 2", due, err)
+	}
+}
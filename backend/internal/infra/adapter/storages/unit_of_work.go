@@ -0,0 +1,31 @@
+package storages
+
+import (
+	"task-management/internal/applications/ports/repository"
+
+	"gorm.io/gorm"
+)
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) repository.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do implements repository.UnitOfWork.
+// Semua repository dibuat ulang dengan *gorm.DB milik transaksi. Transaksi yang dibuka
+// repository di dalam fn (mis. UserRepository.Delete) menjadi savepoint di transaksi ini.
+func (u *unitOfWork) Do(fn func(repos repository.Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(repository.Repositories{
+			Tasks:             NewTaskRepository(tx),
+			Users:             NewUserRepository(tx),
+			Notifications:     NewNotificationRepository(tx),
+			ExternalTaskLinks: NewExternalTaskLinkRepository(tx),
+			LoginAudits:       NewLoginAuditRepository(tx),
+			UserIdentities:    NewUserIdentityRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package storages
+
+import (
+	"errors"
+	"task-management/internal/applications/ports/repository"
+	"task-management/internal/domain"
+	"testing"
+)
+
+func TestUnitOfWorkRollsBackAllRepositories(t *testing.T) {
+	db := openTestDB(t)
+	uow := NewUnitOfWork(db)
+
+	failed := errors.New("failed")
+	err := uow.Do(func(repos repository.Repositories) error {
+		if err := repos.Users.LockAdmins(); err != nil {
+			return err
+		}
+
+		user := &domain.User{Name: "Alice", Username: "alice", Password: "hash"}
+		if err := repos.Users.Create(user); err != nil {
+			return err
+		}
+
+		task := &domain.Task{UserID: user.ID, CreatedBy: user.ID, Title: "task", Status: domain.ToDo}
+		if err := repos.Tasks.Create(task); err != nil {
+			return err
+		}
+
+		if err := repos.Notifications.Create(&domain.Notification{UserID: user.ID, TaskID: &task.ID, Type: domain.NotificationTaskAssigned, Message: "m"}); err != nil {
+			return err
+		}
+
+		if err := repos.ExternalTaskLinks.Save(&domain.ExternalTaskLink{ImportedBy: user.ID, Source: "jira", ExternalID: "1", TaskID: task.ID}); err != nil {
+			return err
+		}
+
+		return failed
+	})
+
+	if !errors.Is(err, failed) {
+		t.Fatalf("Do error = %v, want %v", err, failed)
+	}
+
+	for _, table := range []string{"users", "tasks", "notifications", "external_task_links"} {
+		var total int64
+		if err := db.Table(table).Count(&total).Error; err != nil || total != 0 {
+			t.Errorf("%s rows after rollback = %d, %v", table, total, err)
+		}
+	}
+}
+
+func TestLockAdminsRequiresGuardRow(t *testing.T) {
+	db := openTestDB(t)
+
+	var total int64
+	if err := db.Table("app_locks").Where("name = ?", "admins").Count(&total).Error; err != nil || total != 1 {
+		t.Fatalf("admins guard row = %d, %v, want 1", total, err)
+	}
+
+	if err := NewUserRepository(db).LockAdmins(); err != nil {
+		t.Fatalf("LockAdmins: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package storages
 
 import (
 	"errors"
+	"strings"
 	"task-management/internal/applications/ports/repository"
 	"task-management/internal/domain"
 
@@ -48,3 +49,113 @@ func (u *userRepository) FindByUsername(username string) (*domain.User, error) {
 
 	return &user, nil
 }
+
+// Update implements repository.UserRepository.
+func (u *userRepository) Update(user *domain.User) error {
+	return u.db.Save(user).Error
+}
+
+// CountByRole implements repository.UserRepository.
+func (u *userRepository) CountByRole(role domain.Role) (int64, error) {
+	var total int64
+
+	err := u.db.Model(&domain.User{}).Where("role = ?", role).Count(&total).Error
+	return total, err
+}
+
+// LockAdmins implements repository.UserRepository.
+// Baris "admins" di app_locks dikunci dengan UPDATE, bukan SELECT ... FOR UPDATE, karena SQLite
+// tidak mendukung FOR UPDATE. Tanpa transaksi, kunci langsung dilepas.
+func (u *userRepository) LockAdmins() error {
+	return u.db.Exec("UPDATE app_locks SET name = name WHERE name = ?", "admins").Error
+}
+
+// UpdateTOTPStep implements repository.UserRepository.
+// Dicek dan diubah dalam satu UPDATE supaya dua request dengan kode yang sama tidak sama-sama diterima.
+func (u *userRepository) UpdateTOTPStep(userID uint, step int64) (bool, error) {
+	result := u.db.Model(&domain.User{}).
+		Where("id = ? AND totp_enabled = ? AND (totp_last_step IS NULL OR totp_last_step < ?)", userID, true, step).
+		Update("totp_last_step", step)
+
+	return result.RowsAffected == 1, result.Error
+}
+
+// Search implements repository.UserRepository.
+func (u *userRepository) Search(query string, limit, offset int) ([]domain.User, int64, error) {
+	var users []domain.User
+	var total int64
+
+	q := u.db.Model(&domain.User{})
+
+	if query != "" {
+		// LOWER supaya pencarian tidak case-sensitive di semua database (LIKE di Postgres case-sensitive)
+		like := "%" + strings.ToLower(query) + "%"
+		q = q.Where("LOWER(username) LIKE ? OR LOWER(name) LIKE ? OR LOWER(email) LIKE ?", like, like, like)
+	}
+
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := q.Order("id ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+
+	return users, total, err
+}
+
+// Delete implements repository.UserRepository.
+// Semua data milik user ikut dihapus dalam satu transaksi karena tabel tidak memakai foreign key.
+func (u *userRepository) Delete(id uint) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := deletePersonalData(tx, id); err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", id).Delete(&domain.Task{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("imported_by = ?", id).Delete(&domain.ExternalTaskLink{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&domain.User{}, id).Error
+	})
+}
+
+// Anonymize implements repository.UserRepository.
+func (u *userRepository) Anonymize(user *domain.User) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := deletePersonalData(tx, user.ID); err != nil {
+			return err
+		}
+
+		// Updates tidak meng-insert user yang tidak ada di tabel, seperti user dari store memory
+		return tx.Select("*").Updates(user).Error
+	})
+}
+
+// deletePersonalData menghapus semua data milik user selain task.
+func deletePersonalData(tx *gorm.DB, userID uint) error {
+	owned := []any{
+		&domain.Notification{},
+		&domain.NotificationPreference{},
+		&domain.CalendarFeed{},
+		&domain.APIToken{},
+		&domain.RecoveryCode{},
+		&domain.PasswordResetToken{},
+		&domain.EmailVerificationToken{},
+		&domain.UserIdentity{},
+		&domain.Session{},
+	}
+
+	for _, model := range owned {
+		if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,15 +2,18 @@ package db
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"task-management/internal/config"
-	"task-management/internal/domain"
 	"task-management/internal/infra/logger"
-	"task-management/internal/utils"
 	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -18,44 +21,92 @@ type Database struct {
 	DB *gorm.DB
 }
 
+// NewDatabase membuka koneksi lalu menjalankan migrasi yang belum dijalankan. Jika
+// database.auto_migrate false, migrasi harus dijalankan manual dengan `migrate up`.
 func NewDatabase(cfg config.DatabaseConfig) (*Database, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		cfg.User,
-		cfg.Password,
-		cfg.Host,
-		cfg.Port,
-		cfg.Name,
-	)
+	database, err := Connect(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	gormConfig := &gorm.Config{}
+	if err := database.migrateOnStart(cfg.AutoMigrateEnabled()); err != nil {
+		_ = database.Close()
+		return nil, err
+	}
 
-	db, err := gorm.Open(mysql.Open(dsn), gormConfig)
+	return database, nil
+}
 
-	sqlDB, err := db.DB()
+func (d *Database) migrateOnStart(enabled bool) error {
+	migrator, err := NewMigrator(d.DB)
+	if err != nil {
+		return err
+	}
+
+	if !enabled {
+		pending, err := migrator.Pending()
+		if err != nil {
+			return err
+		}
+
+		if len(pending) > 0 {
+			logger.Warn("Database has pending migrations, run `migrate up`", zap.Int("pending", len(pending)))
+		}
+
+		return nil
+	}
+
+	applied, err := migrator.Up(0)
+	for _, migration := range applied {
+		logger.Info("Migration applied", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect DB: %w", err)
+		logger.Error("Failed to run migrations", zap.Error(err))
+		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	// jalankan migrasi otomatis
-	err = db.AutoMigrate(
-		&domain.User{},
-		&domain.Task{},
-	)
+	return nil
+}
 
+// Connect membuka koneksi database tanpa menjalankan migrasi.
+func Connect(cfg config.DatabaseConfig) (*Database, error) {
+	dialector, err := newDialector(cfg)
 	if err != nil {
-		logger.Error("Failed to run auto migration", zap.Error(err))
-		return nil, fmt.Errorf("failed to run auto migration: %w", err)
+		return nil, err
+	}
+
+	// semua waktu disimpan dalam UTC, konversi ke zona waktu user dilakukan di layer HTTP.
+	// TranslateError mengubah pelanggaran unique index di semua driver menjadi gorm.ErrDuplicatedKey.
+	gormConfig := &gorm.Config{
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+		TranslateError: true,
+	}
+
+	db, err := gorm.Open(dialector, gormConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect DB: %w", err)
 	}
 
-	// buat user default jika belum ada
-	createDefaultUser(db)
+	sqlDB, err := db.DB()
 
-	sqlDB.SetMaxOpenConns(cfg.MaxOpenCons)
-	sqlDB.SetMaxIdleConns(cfg.MaxIdleCons)
-	sqlDB.SetConnMaxLifetime(time.Duration(cfg.MaxLifeTime) * time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect DB: %w", err)
+	}
+
+	if dialector.Name() == "sqlite" {
+		// SQLite hanya mengizinkan satu penulis, dan setiap koneksi ke :memory: adalah database terpisah
+		sqlDB.SetMaxOpenConns(1)
+	} else {
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenCons)
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleCons)
+		sqlDB.SetConnMaxLifetime(time.Duration(cfg.MaxLifeTime) * time.Minute)
+	}
 
 	logger.Info("Database connected successfully",
+		zap.String("driver", dialector.Name()),
 		zap.String("host", cfg.Host),
 		zap.String("name", cfg.Name),
 	)
@@ -63,8 +114,57 @@ func NewDatabase(cfg config.DatabaseConfig) (*Database, error) {
 	return &Database{DB: db}, nil
 }
 
+// newDialector memilih driver gorm dari cfg.Driver. Driver kosong berarti mysql.
+// Untuk sqlite, Name adalah path file database atau ":memory:".
+func newDialector(cfg config.DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", "mysql":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
+			cfg.User,
+			cfg.Password,
+			cfg.Host,
+			cfg.Port,
+			cfg.Name,
+		)
+
+		return mysql.Open(dsn), nil
+	case "postgres":
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+			cfg.Host,
+			cfg.Port,
+			cfg.User,
+			cfg.Password,
+			cfg.Name,
+			stringOrDefault(cfg.SSLMode, "disable"),
+		)
+
+		return postgres.Open(dsn), nil
+	case "sqlite":
+		if cfg.Name == "" {
+			return nil, errors.New("database.name must be the SQLite file path or :memory:")
+		}
+
+		if cfg.Name != ":memory:" {
+			if err := os.MkdirAll(filepath.Dir(cfg.Name), 0o755); err != nil {
+				return nil, fmt.Errorf("failed to create SQLite directory: %w", err)
+			}
+		}
+
+		return sqlite.Open(cfg.Name + "?_foreign_keys=on&_busy_timeout=5000"), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q, use mysql, postgres or sqlite", cfg.Driver)
+	}
+}
+
+func stringOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
 func (d *Database) Close() error {
-	if d.DB != nil {
+	if d.DB == nil {
 		return nil
 	}
 
@@ -82,31 +182,3 @@ func (d *Database) Close() error {
 
 	return nil
 }
-
-func createDefaultUser(db *gorm.DB) {
-	const defaultUsername = "admin"
-	const defaultPassword = "admin123"
-
-	var user domain.User
-	result := db.First(&user, "username = ?", defaultUsername)
-
-	if result.Error == gorm.ErrRecordNotFound {
-		hashed, _ := utils.HashPassword(defaultPassword)
-
-		newUser := domain.User{
-			Username: defaultUsername,
-			Password: string(hashed),
-		}
-
-		if err := db.Create(&newUser).Error; err != nil {
-			logger.Info("failed to create default user")
-		} else {
-			logger.Info("Default user created",
-				zap.String("username", defaultUsername),
-				zap.String("password", defaultPassword),
-			)
-		}
-	} else {
-		fmt.Println("Default user already exists")
-	}
-}
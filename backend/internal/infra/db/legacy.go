@@ -0,0 +1,125 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var createTablePattern = regexp.MustCompile("(?is)^CREATE TABLE IF NOT EXISTS\\s+[`\"]?(\\w+)[`\"]?\\s*\\((.*)\\)[^)]*;?$")
+
+// isLegacySchema mengenali database yang dibuat oleh gorm AutoMigrate sebelum ada migrasi:
+// belum ada migrasi yang dijalankan tetapi tabel users sudah ada.
+func isLegacySchema(db *gorm.DB, applied map[int64]SchemaMigration) bool {
+	return len(applied) == 0 && db.Migrator().HasTable("users")
+}
+
+// upgradeLegacySchema menyamakan tabel lama dengan CREATE TABLE di script migrasi awal.
+// CREATE TABLE IF NOT EXISTS melewati tabel yang sudah ada, sehingga kolom dan index yang
+// ditambahkan setelah tabel itu dibuat AutoMigrate (mis. users.email, users.role, tasks.labels)
+// ditambahkan di sini dengan ALTER TABLE memakai definisi kolom dari script yang sama.
+func upgradeLegacySchema(tx *gorm.DB, script string) error {
+	for _, statement := range splitStatements(script) {
+		match := createTablePattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+
+		table := match[1]
+		if !tx.Migrator().HasTable(table) {
+			continue
+		}
+
+		for _, item := range splitDefinitions(match[2]) {
+			if err := upgradeDefinition(tx, table, item); err != nil {
+				return fmt.Errorf("upgrade table %s: %w", table, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// upgradeDefinition menambahkan satu kolom atau index (index inline di CREATE TABLE MySQL)
+// jika belum ada. Primary key tidak pernah berubah sejak skema awal.
+func upgradeDefinition(tx *gorm.DB, table, item string) error {
+	fields := strings.Fields(item)
+	keyword := strings.ToUpper(fields[0])
+
+	switch keyword {
+	case "PRIMARY", "CONSTRAINT":
+		return nil
+	case "INDEX", "KEY", "UNIQUE":
+		unique := ""
+		if keyword == "UNIQUE" {
+			unique = "UNIQUE "
+			fields = fields[1:]
+		}
+
+		// fields: INDEX `name` (`col`, ...)
+		name := unquoteIdentifier(fields[1])
+		if tx.Migrator().HasIndex(table, name) {
+			return nil
+		}
+
+		columns := item[strings.Index(item, "("):]
+		return tx.Exec(fmt.Sprintf("CREATE %sINDEX %s ON %s %s", unique, fields[1], quoteLike(fields[1], table), columns)).Error
+	}
+
+	if tx.Migrator().HasColumn(table, unquoteIdentifier(fields[0])) {
+		return nil
+	}
+
+	return tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", quoteLike(fields[0], table), item)).Error
+}
+
+// splitDefinitions memecah isi CREATE TABLE per koma di level teratas,
+// koma di dalam kurung (varchar(100), index) atau string default tidak dihitung.
+func splitDefinitions(body string) []string {
+	var items []string
+	var current strings.Builder
+	depth := 0
+	var quote rune
+
+	for _, r := range body {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, strings.TrimSpace(current.String()))
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		items = append(items, rest)
+	}
+
+	return items
+}
+
+func unquoteIdentifier(name string) string {
+	return strings.Trim(name, "`\"")
+}
+
+// quoteLike memberi tanda kutip ke name dengan gaya yang sama seperti reference (` atau ").
+func quoteLike(reference, name string) string {
+	if q := reference[:1]; q == "`" || q == `"` {
+		return q + name + q
+	}
+
+	return name
+}
@@ -0,0 +1,235 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"task-management/internal/config"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func openSQLite(t *testing.T) *Database {
+	t.Helper()
+
+	database, err := Connect(config.DatabaseConfig{Driver: "sqlite", Name: ":memory:"})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = database.Close() })
+	return database
+}
+
+func TestMigratorUpDown(t *testing.T) {
+	database := openSQLite(t)
+
+	migrator, err := NewMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	applied, err := migrator.Up(0)
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	if len(applied) != len(migrator.migrations) {
+		t.Fatalf("applied %d migrations, want %d", len(applied), len(migrator.migrations))
+	}
+
+	pending, err := migrator.Pending()
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("pending after up = %v, %v", pending, err)
+	}
+
+	for _, table := range []string{"users", "tasks", "sessions", "api_tokens"} {
+		if !database.DB.Migrator().HasTable(table) {
+			t.Errorf("table %s not created", table)
+		}
+	}
+
+	rolledBack, err := migrator.Down(len(applied))
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+
+	if len(rolledBack) != len(applied) {
+		t.Fatalf("rolled back %d migrations, want %d", len(rolledBack), len(applied))
+	}
+
+	if database.DB.Migrator().HasTable("users") {
+		t.Error("users still exists after down")
+	}
+}
+
+// database yang dibuat AutoMigrate versi awal hanya punya kolom dasar users dan tasks
+func TestMigratorUpgradesLegacySchema(t *testing.T) {
+	database := openSQLite(t)
+
+	legacy := []string{
+		"CREATE TABLE `users` (`id` integer PRIMARY KEY AUTOINCREMENT,`name` text NOT NULL,`username` text NOT NULL,`password` text NOT NULL,`created_at` datetime)",
+		"CREATE UNIQUE INDEX `idx_users_username` ON `users`(`username`)",
+		"CREATE TABLE `tasks` (`id` integer PRIMARY KEY AUTOINCREMENT,`user_id` integer NOT NULL,`title` text NOT NULL,`description` text,`status` text NOT NULL DEFAULT \"To Do\",`deadline` datetime,`created_by` integer,`created_at` datetime)",
+		"INSERT INTO `users` (`name`,`username`,`password`,`created_at`) VALUES ('Admin','admin','hash',CURRENT_TIMESTAMP)",
+		"INSERT INTO `tasks` (`user_id`,`title`,`status`,`created_by`,`created_at`) VALUES (1,'Old task','To Do',1,CURRENT_TIMESTAMP)",
+	}
+
+	for _, statement := range legacy {
+		if err := database.DB.Exec(statement).Error; err != nil {
+			t.Fatalf("legacy schema: %v", err)
+		}
+	}
+
+	migrator, err := NewMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	for table, columns := range map[string][]string{
+		"users": {"email", "role", "timezone", "totp_secret", "totp_enabled", "deactivated_at", "password_changed_at", "avatar_updated_at", "email_verified_at"},
+		"tasks": {"labels", "completed_at"},
+	} {
+		for _, column := range columns {
+			if !database.DB.Migrator().HasColumn(table, column) {
+				t.Errorf("column %s.%s not added", table, column)
+			}
+		}
+	}
+
+	var role string
+	if err := database.DB.Raw("SELECT role FROM users WHERE username = ?", "admin").Scan(&role).Error; err != nil {
+		t.Fatalf("select role: %v", err)
+	}
+
+	if role != "user" {
+		t.Errorf("existing user role = %q, want default %q", role, "user")
+	}
+
+	var total int64
+	if err := database.DB.Table("tasks").Where("labels IS NULL AND completed_at IS NULL").Count(&total).Error; err != nil || total != 1 {
+		t.Errorf("query new task columns = %d, %v", total, err)
+	}
+}
+
+// waktu task yang tersimpan dengan offset non-UTC diubah ke UTC supaya perbandingan teks benar
+func TestMigratorNormalizesTaskTimesToUTC(t *testing.T) {
+	database := openSQLite(t)
+
+	migrator, err := NewMigrator(database.DB)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+
+	if _, err := migrator.Down(1); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Date(2026, 3, 1, 23, 59, 59, 0, jakarta)
+	insert := "INSERT INTO tasks (user_id, title, description, status, deadline, completed_at, created_at) VALUES (1, 'Report', '', 'Done', ?, ?, ?)"
+	if err := database.DB.Exec(insert, deadline, deadline, deadline).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := migrator.Up(0)
+	if err != nil || len(applied) != 1 || applied[0].Name != "task_times_utc" {
+		t.Fatalf("up = %v, %v, want task_times_utc", applied, err)
+	}
+
+	var row struct {
+		Deadline    string
+		CompletedAt string
+	}
+	if err := database.DB.Raw("SELECT CAST(deadline AS TEXT) AS deadline, CAST(completed_at AS TEXT) AS completed_at FROM tasks").Scan(&row).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for column, value := range map[string]string{"deadline": row.Deadline, "completed_at": row.CompletedAt} {
+		if value != "2026-03-01 16:59:59.000+00:00" {
+			t.Errorf("%s = %q, want UTC", column, value)
+		}
+	}
+
+	var count int64
+	dueBefore := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	if err := database.DB.Table("tasks").Where("deadline < ?", dueBefore).Count(&count).Error; err != nil || count != 1 {
+		t.Errorf("tasks due before %v = %d, %v, want 1", dueBefore, count, err)
+	}
+}
+
+func TestSplitDefinitions(t *testing.T) {
+	body := "`id` bigint unsigned AUTO_INCREMENT, `status` varchar(20) NOT NULL DEFAULT 'To, Do', PRIMARY KEY (`id`), INDEX `idx_a_b` (`a`,`b`)"
+
+	got := splitDefinitions(body)
+	want := []string{
+		"`id` bigint unsigned AUTO_INCREMENT",
+		"`status` varchar(20) NOT NULL DEFAULT 'To, Do'",
+		"PRIMARY KEY (`id`)",
+		"INDEX `idx_a_b` (`a`,`b`)",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("splitDefinitions = %q, want %q", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLoadMigrationsRequiresUpAndDown(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/20260101000000_init.up.sql": {Data: []byte("CREATE TABLE a (id int);")},
+	}
+
+	if _, err := loadMigrations(fsys, "m"); err == nil {
+		t.Fatal("expected error for migration without down script")
+	}
+
+	fsys["m/20260101000000_init.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE a;")}
+
+	migrations, err := loadMigrations(fsys, "m")
+	if err != nil || len(migrations) != 1 || migrations[0].Name != "init" {
+		t.Fatalf("loadMigrations = %v, %v", migrations, err)
+	}
+}
+
+func TestCreateMigrationVersionIsUTC(t *testing.T) {
+	dir := t.TempDir()
+	for _, dialect := range Dialects {
+		if err := os.MkdirAll(filepath.Join(dir, dialect), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2026, 10, 19, 7, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+
+	files, err := CreateMigration(dir, "Add Task Priority", now)
+	if err != nil {
+		t.Fatalf("create migration: %v", err)
+	}
+
+	if len(files) != len(Dialects)*2 {
+		t.Fatalf("created %d files, want %d", len(files), len(Dialects)*2)
+	}
+
+	if want := "20261019000000_add_task_priority.up.sql"; !strings.HasSuffix(files[0], want) {
+		t.Errorf("first file = %s, want suffix %s", files[0], want)
+	}
+}
@@ -0,0 +1,195 @@
+-- Skema awal, sama dengan tabel yang sebelumnya dibuat oleh gorm AutoMigrate.
+-- Database lama dari AutoMigrate: tabel yang belum ada dibuat, kolom dan index yang belum ada
+-- ditambahkan oleh migrator dari definisi di bawah (lihat upgradeLegacySchema).
+
+CREATE TABLE IF NOT EXISTS `users` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `name` varchar(100) NOT NULL,
+    `username` varchar(100) NOT NULL,
+    `email` varchar(255),
+    `password` varchar(255) NOT NULL,
+    `created_at` datetime(3) NULL,
+    `email_verified_at` datetime(3) NULL,
+    `timezone` varchar(64),
+    `locale` varchar(16),
+    `avatar_updated_at` datetime(3) NULL,
+    `role` varchar(20) NOT NULL DEFAULT 'user',
+    `deactivated_at` datetime(3) NULL,
+    `password_changed_at` datetime(3) NULL,
+    `totp_secret` varchar(64),
+    `totp_enabled` boolean NOT NULL DEFAULT false,
+    `totp_last_step` bigint,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_users_username` (`username`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `tasks` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `title` varchar(255) NOT NULL,
+    `description` text,
+    `status` varchar(20) NOT NULL DEFAULT 'To Do',
+    `deadline` datetime(3) NULL,
+    `labels` text,
+    `created_by` bigint unsigned,
+    `completed_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_tasks_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `notifications` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `task_id` bigint unsigned,
+    `type` varchar(50) NOT NULL,
+    `message` varchar(255) NOT NULL,
+    `read_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_notifications_user_id` (`user_id`),
+    INDEX `idx_notifications_task_id` (`task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `notification_preferences` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `email_enabled` boolean NOT NULL,
+    `task_assigned` boolean NOT NULL,
+    `deadline_reminder` boolean NOT NULL,
+    `digest` varchar(10) NOT NULL,
+    `updated_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_notification_preferences_user_id` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `calendar_feeds` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `token_hash` varchar(64) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_calendar_feeds_user_id` (`user_id`),
+    UNIQUE INDEX `idx_calendar_feeds_token_hash` (`token_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `external_task_links` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `imported_by` bigint unsigned NOT NULL,
+    `source` varchar(20) NOT NULL,
+    `external_id` varchar(191) NOT NULL,
+    `task_id` bigint unsigned NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_external_task` (`imported_by`,`source`,`external_id`),
+    INDEX `idx_external_task_links_task_id` (`task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `api_tokens` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `prefix` varchar(16) NOT NULL,
+    `token_hash` varchar(64) NOT NULL,
+    `scopes` text,
+    `expires_at` datetime(3) NULL,
+    `last_used_at` datetime(3) NULL,
+    `revoked_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_api_tokens_user_id` (`user_id`),
+    UNIQUE INDEX `idx_api_tokens_token_hash` (`token_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `login_attempts` (
+    `attempt_key` varchar(191),
+    `failures` bigint NOT NULL,
+    `first_failure` datetime(3) NULL,
+    `last_failure` datetime(3) NULL,
+    `locked_until` datetime(3) NULL,
+    PRIMARY KEY (`attempt_key`),
+    INDEX `idx_login_attempts_last_failure` (`last_failure`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `login_audits` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `username` varchar(100),
+    `ip` varchar(45),
+    `reason` varchar(50) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_login_audits_username` (`username`),
+    INDEX `idx_login_audits_ip` (`ip`),
+    INDEX `idx_login_audits_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `recovery_codes` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `code_hash` varchar(64) NOT NULL,
+    `used_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_recovery_codes_user_id` (`user_id`),
+    INDEX `idx_recovery_codes_code_hash` (`code_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `password_reset_tokens` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `token_hash` varchar(64) NOT NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `used_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_password_reset_tokens_user_id` (`user_id`),
+    UNIQUE INDEX `idx_password_reset_tokens_token_hash` (`token_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `user_identities` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `issuer` varchar(191) NOT NULL,
+    `subject` varchar(191) NOT NULL,
+    `email` varchar(255),
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_user_identities_user_id` (`user_id`),
+    UNIQUE INDEX `idx_identity_subject` (`issuer`,`subject`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `o_id_c_login_states` (
+    `state` varchar(64),
+    `nonce` varchar(64) NOT NULL,
+    `code_verifier` varchar(128) NOT NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`state`),
+    INDEX `idx_o_id_c_login_states_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `sessions` (
+    `id` varchar(32),
+    `user_id` bigint unsigned NOT NULL,
+    `user_agent` varchar(255),
+    `ip` varchar(45),
+    `created_at` datetime(3) NULL,
+    `last_seen_at` datetime(3) NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `revoked_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_sessions_user_id` (`user_id`),
+    INDEX `idx_sessions_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `email_verification_tokens` (
+    `id` bigint unsigned AUTO_INCREMENT,
+    `user_id` bigint unsigned NOT NULL,
+    `email` varchar(255) NOT NULL,
+    `token_hash` varchar(64) NOT NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_email_verification_tokens_user_id` (`user_id`),
+    UNIQUE INDEX `idx_email_verification_tokens_token_hash` (`token_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,10 @@
+-- Baris penjaga untuk operasi yang harus berjalan satu per satu (setup admin pertama dan pengecekan
+-- admin terakhir). Baris dikunci dengan UPDATE di dalam transaksi, transaksi lain yang mengunci baris
+-- yang sama menunggu sampai transaksi pertama selesai.
+
+CREATE TABLE IF NOT EXISTS `app_locks` (
+    `name` varchar(64) NOT NULL,
+    PRIMARY KEY (`name`)
+);
+
+INSERT IGNORE INTO `app_locks` (`name`) VALUES ('admins');
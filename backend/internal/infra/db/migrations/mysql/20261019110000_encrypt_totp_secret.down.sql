@@ -0,0 +1,4 @@
+-- Secret terenkripsi tidak muat di kolom lama, 2FA user tersebut dimatikan dan harus diaktifkan ulang.
+
+UPDATE `users` SET `totp_secret` = NULL, `totp_enabled` = false, `totp_last_step` = 0 WHERE LENGTH(`totp_secret`) > 64;
+ALTER TABLE `users` MODIFY `totp_secret` varchar(64);
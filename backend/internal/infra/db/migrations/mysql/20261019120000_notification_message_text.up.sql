@@ -0,0 +1,3 @@
+-- Pesan notifikasi berisi judul task yang bisa sampai 255 karakter, tidak muat di varchar(255).
+
+ALTER TABLE `notifications` MODIFY `message` text NOT NULL;
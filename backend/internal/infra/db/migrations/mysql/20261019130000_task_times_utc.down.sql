@@ -0,0 +1 @@
+-- Kolom waktu di mysql dibandingkan sebagai timestamp, tidak ada yang perlu diubah.
@@ -0,0 +1,195 @@
+-- Skema awal, sama dengan tabel yang sebelumnya dibuat oleh gorm AutoMigrate.
+-- Database lama dari AutoMigrate: tabel yang belum ada dibuat, kolom dan index yang belum ada
+-- ditambahkan oleh migrator dari definisi di bawah (lihat upgradeLegacySchema).
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" bigserial,
+    "name" varchar(100) NOT NULL,
+    "username" varchar(100) NOT NULL,
+    "email" varchar(255),
+    "password" varchar(255) NOT NULL,
+    "created_at" timestamptz,
+    "email_verified_at" timestamptz,
+    "timezone" varchar(64),
+    "locale" varchar(16),
+    "avatar_updated_at" timestamptz,
+    "role" varchar(20) NOT NULL DEFAULT 'user',
+    "deactivated_at" timestamptz,
+    "password_changed_at" timestamptz,
+    "totp_secret" varchar(64),
+    "totp_enabled" boolean NOT NULL DEFAULT false,
+    "totp_last_step" bigint,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+
+CREATE TABLE IF NOT EXISTS "tasks" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "title" varchar(255) NOT NULL,
+    "description" text,
+    "status" varchar(20) NOT NULL DEFAULT 'To Do',
+    "deadline" timestamptz,
+    "labels" text,
+    "created_by" bigint,
+    "completed_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_tasks_user_id" ON "tasks" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "task_id" bigint,
+    "type" varchar(50) NOT NULL,
+    "message" varchar(255) NOT NULL,
+    "read_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_notifications_task_id" ON "notifications" ("task_id");
+CREATE INDEX IF NOT EXISTS "idx_notifications_user_id" ON "notifications" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "notification_preferences" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "email_enabled" boolean NOT NULL,
+    "task_assigned" boolean NOT NULL,
+    "deadline_reminder" boolean NOT NULL,
+    "digest" varchar(10) NOT NULL,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_notification_preferences_user_id" ON "notification_preferences" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "calendar_feeds" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feeds_token_hash" ON "calendar_feeds" ("token_hash");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feeds_user_id" ON "calendar_feeds" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "external_task_links" (
+    "id" bigserial,
+    "imported_by" bigint NOT NULL,
+    "source" varchar(20) NOT NULL,
+    "external_id" varchar(191) NOT NULL,
+    "task_id" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_external_task_links_task_id" ON "external_task_links" ("task_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_external_task" ON "external_task_links" ("imported_by","source","external_id");
+
+CREATE TABLE IF NOT EXISTS "api_tokens" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "prefix" varchar(16) NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "scopes" text,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_tokens_token_hash" ON "api_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_api_tokens_user_id" ON "api_tokens" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "login_attempts" (
+    "attempt_key" varchar(191),
+    "failures" bigint NOT NULL,
+    "first_failure" timestamptz,
+    "last_failure" timestamptz,
+    "locked_until" timestamptz,
+    PRIMARY KEY ("attempt_key")
+);
+CREATE INDEX IF NOT EXISTS "idx_login_attempts_last_failure" ON "login_attempts" ("last_failure");
+
+CREATE TABLE IF NOT EXISTS "login_audits" (
+    "id" bigserial,
+    "username" varchar(100),
+    "ip" varchar(45),
+    "reason" varchar(50) NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_login_audits_created_at" ON "login_audits" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_login_audits_ip" ON "login_audits" ("ip");
+CREATE INDEX IF NOT EXISTS "idx_login_audits_username" ON "login_audits" ("username");
+
+CREATE TABLE IF NOT EXISTS "recovery_codes" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "code_hash" varchar(64) NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_recovery_codes_code_hash" ON "recovery_codes" ("code_hash");
+CREATE INDEX IF NOT EXISTS "idx_recovery_codes_user_id" ON "recovery_codes" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "password_reset_tokens" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_password_reset_tokens_token_hash" ON "password_reset_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_password_reset_tokens_user_id" ON "password_reset_tokens" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "user_identities" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "issuer" varchar(191) NOT NULL,
+    "subject" varchar(191) NOT NULL,
+    "email" varchar(255),
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_identity_subject" ON "user_identities" ("issuer","subject");
+CREATE INDEX IF NOT EXISTS "idx_user_identities_user_id" ON "user_identities" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "o_id_c_login_states" (
+    "state" varchar(64),
+    "nonce" varchar(64) NOT NULL,
+    "code_verifier" varchar(128) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("state")
+);
+CREATE INDEX IF NOT EXISTS "idx_o_id_c_login_states_expires_at" ON "o_id_c_login_states" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "sessions" (
+    "id" varchar(32),
+    "user_id" bigint NOT NULL,
+    "user_agent" varchar(255),
+    "ip" varchar(45),
+    "created_at" timestamptz,
+    "last_seen_at" timestamptz,
+    "expires_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_sessions_expires_at" ON "sessions" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_sessions_user_id" ON "sessions" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "email_verification_tokens" (
+    "id" bigserial,
+    "user_id" bigint NOT NULL,
+    "email" varchar(255) NOT NULL,
+    "token_hash" varchar(64) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_email_verification_tokens_token_hash" ON "email_verification_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_email_verification_tokens_user_id" ON "email_verification_tokens" ("user_id");
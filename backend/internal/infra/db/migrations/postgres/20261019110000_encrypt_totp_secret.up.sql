@@ -0,0 +1,3 @@
+-- Secret TOTP disimpan terenkripsi (AES-GCM, base64) dan lebih panjang dari 64 karakter.
+
+ALTER TABLE "users" ALTER COLUMN "totp_secret" TYPE varchar(255);
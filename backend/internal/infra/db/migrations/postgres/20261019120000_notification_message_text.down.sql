@@ -0,0 +1,4 @@
+-- Pesan yang lebih panjang dari 255 karakter dipotong supaya muat di kolom lama.
+
+UPDATE "notifications" SET "message" = LEFT("message", 255) WHERE CHAR_LENGTH("message") > 255;
+ALTER TABLE "notifications" ALTER COLUMN "message" TYPE varchar(255);
@@ -0,0 +1 @@
+-- Kolom waktu di postgres dibandingkan sebagai timestamp, tidak ada yang perlu diubah.
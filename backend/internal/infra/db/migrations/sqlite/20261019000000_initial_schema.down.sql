@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS `email_verification_tokens`;
+DROP TABLE IF EXISTS `sessions`;
+DROP TABLE IF EXISTS `o_id_c_login_states`;
+DROP TABLE IF EXISTS `user_identities`;
+DROP TABLE IF EXISTS `password_reset_tokens`;
+DROP TABLE IF EXISTS `recovery_codes`;
+DROP TABLE IF EXISTS `login_audits`;
+DROP TABLE IF EXISTS `login_attempts`;
+DROP TABLE IF EXISTS `api_tokens`;
+DROP TABLE IF EXISTS `external_task_links`;
+DROP TABLE IF EXISTS `calendar_feeds`;
+DROP TABLE IF EXISTS `notification_preferences`;
+DROP TABLE IF EXISTS `notifications`;
+DROP TABLE IF EXISTS `tasks`;
+DROP TABLE IF EXISTS `users`;
@@ -0,0 +1,183 @@
+-- Skema awal, sama dengan tabel yang sebelumnya dibuat oleh gorm AutoMigrate.
+-- Database lama dari AutoMigrate: tabel yang belum ada dibuat, kolom dan index yang belum ada
+-- ditambahkan oleh migrator dari definisi di bawah (lihat upgradeLegacySchema).
+
+CREATE TABLE IF NOT EXISTS `users` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `name` text NOT NULL,
+    `username` text NOT NULL,
+    `email` text,
+    `password` text NOT NULL,
+    `created_at` datetime,
+    `email_verified_at` datetime,
+    `timezone` text,
+    `locale` text,
+    `avatar_updated_at` datetime,
+    `role` text NOT NULL DEFAULT 'user',
+    `deactivated_at` datetime,
+    `password_changed_at` datetime,
+    `totp_secret` text,
+    `totp_enabled` numeric NOT NULL DEFAULT false,
+    `totp_last_step` integer
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_users_username` ON `users`(`username`);
+
+CREATE TABLE IF NOT EXISTS `tasks` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `title` text NOT NULL,
+    `description` text,
+    `status` text NOT NULL DEFAULT 'To Do',
+    `deadline` datetime,
+    `labels` text,
+    `created_by` integer,
+    `completed_at` datetime,
+    `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_tasks_user_id` ON `tasks`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `notifications` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `task_id` integer,
+    `type` text NOT NULL,
+    `message` text NOT NULL,
+    `read_at` datetime,
+    `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_notifications_task_id` ON `notifications`(`task_id`);
+CREATE INDEX IF NOT EXISTS `idx_notifications_user_id` ON `notifications`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `notification_preferences` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `email_enabled` numeric NOT NULL,
+    `task_assigned` numeric NOT NULL,
+    `deadline_reminder` numeric NOT NULL,
+    `digest` text NOT NULL,
+    `updated_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_notification_preferences_user_id` ON `notification_preferences`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `calendar_feeds` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `token_hash` text NOT NULL,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_calendar_feeds_token_hash` ON `calendar_feeds`(`token_hash`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_calendar_feeds_user_id` ON `calendar_feeds`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `external_task_links` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `imported_by` integer NOT NULL,
+    `source` text NOT NULL,
+    `external_id` text NOT NULL,
+    `task_id` integer NOT NULL,
+    `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_external_task_links_task_id` ON `external_task_links`(`task_id`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_external_task` ON `external_task_links`(`imported_by`,`source`,`external_id`);
+
+CREATE TABLE IF NOT EXISTS `api_tokens` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `name` text NOT NULL,
+    `prefix` text NOT NULL,
+    `token_hash` text NOT NULL,
+    `scopes` text,
+    `expires_at` datetime,
+    `last_used_at` datetime,
+    `revoked_at` datetime,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_api_tokens_token_hash` ON `api_tokens`(`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_api_tokens_user_id` ON `api_tokens`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `login_attempts` (
+    `attempt_key` text,
+    `failures` integer NOT NULL,
+    `first_failure` datetime,
+    `last_failure` datetime,
+    `locked_until` datetime,
+    PRIMARY KEY (`attempt_key`)
+);
+CREATE INDEX IF NOT EXISTS `idx_login_attempts_last_failure` ON `login_attempts`(`last_failure`);
+
+CREATE TABLE IF NOT EXISTS `login_audits` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `username` text,
+    `ip` text,
+    `reason` text NOT NULL,
+    `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_login_audits_created_at` ON `login_audits`(`created_at`);
+CREATE INDEX IF NOT EXISTS `idx_login_audits_ip` ON `login_audits`(`ip`);
+CREATE INDEX IF NOT EXISTS `idx_login_audits_username` ON `login_audits`(`username`);
+
+CREATE TABLE IF NOT EXISTS `recovery_codes` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `code_hash` text NOT NULL,
+    `used_at` datetime,
+    `created_at` datetime
+);
+CREATE INDEX IF NOT EXISTS `idx_recovery_codes_code_hash` ON `recovery_codes`(`code_hash`);
+CREATE INDEX IF NOT EXISTS `idx_recovery_codes_user_id` ON `recovery_codes`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `password_reset_tokens` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `token_hash` text NOT NULL,
+    `expires_at` datetime NOT NULL,
+    `used_at` datetime,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_password_reset_tokens_token_hash` ON `password_reset_tokens`(`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_password_reset_tokens_user_id` ON `password_reset_tokens`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `user_identities` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `issuer` text NOT NULL,
+    `subject` text NOT NULL,
+    `email` text,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_identity_subject` ON `user_identities`(`issuer`,`subject`);
+CREATE INDEX IF NOT EXISTS `idx_user_identities_user_id` ON `user_identities`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `o_id_c_login_states` (
+    `state` text,
+    `nonce` text NOT NULL,
+    `code_verifier` text NOT NULL,
+    `expires_at` datetime NOT NULL,
+    `created_at` datetime,
+    PRIMARY KEY (`state`)
+);
+CREATE INDEX IF NOT EXISTS `idx_o_id_c_login_states_expires_at` ON `o_id_c_login_states`(`expires_at`);
+
+CREATE TABLE IF NOT EXISTS `sessions` (
+    `id` text,
+    `user_id` integer NOT NULL,
+    `user_agent` text,
+    `ip` text,
+    `created_at` datetime,
+    `last_seen_at` datetime,
+    `expires_at` datetime NOT NULL,
+    `revoked_at` datetime,
+    PRIMARY KEY (`id`)
+);
+CREATE INDEX IF NOT EXISTS `idx_sessions_expires_at` ON `sessions`(`expires_at`);
+CREATE INDEX IF NOT EXISTS `idx_sessions_user_id` ON `sessions`(`user_id`);
+
+CREATE TABLE IF NOT EXISTS `email_verification_tokens` (
+    `id` integer PRIMARY KEY AUTOINCREMENT,
+    `user_id` integer NOT NULL,
+    `email` text NOT NULL,
+    `token_hash` text NOT NULL,
+    `expires_at` datetime NOT NULL,
+    `created_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_email_verification_tokens_token_hash` ON `email_verification_tokens`(`token_hash`);
+CREATE INDEX IF NOT EXISTS `idx_email_verification_tokens_user_id` ON `email_verification_tokens`(`user_id`);
@@ -0,0 +1,9 @@
+-- Baris penjaga untuk operasi yang harus berjalan satu per satu (setup admin pertama dan pengecekan
+-- admin terakhir). Baris dikunci dengan UPDATE di dalam transaksi, transaksi lain yang mengunci baris
+-- yang sama menunggu sampai transaksi pertama selesai.
+
+CREATE TABLE IF NOT EXISTS `app_locks` (
+    `name` text PRIMARY KEY
+);
+
+INSERT OR IGNORE INTO `app_locks` (`name`) VALUES ('admins');
@@ -0,0 +1,2 @@
+-- Secret TOTP disimpan terenkripsi (AES-GCM, base64) dan lebih panjang dari 64 karakter.
+-- Kolom text di SQLite tidak punya batas panjang, tidak ada yang perlu diubah.
@@ -0,0 +1 @@
+-- Kolom text di SQLite tidak punya batas panjang, tidak ada yang perlu diubah.
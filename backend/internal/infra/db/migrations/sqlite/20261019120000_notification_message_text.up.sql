@@ -0,0 +1,2 @@
+-- Kolom text di SQLite tidak punya batas panjang, tidak ada yang perlu diubah.
+-- Pesan notifikasi berisi judul task yang bisa sampai 255 karakter, tidak muat di varchar(255).
@@ -0,0 +1 @@
+-- Offset asli tidak disimpan, waktu tetap dalam UTC.
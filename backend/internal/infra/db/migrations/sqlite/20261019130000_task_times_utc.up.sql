@@ -0,0 +1,10 @@
+-- SQLite menyimpan waktu sebagai teks dengan offset zona waktu saat ditulis, sehingga
+-- "deadline < ?" membandingkan string dengan offset berbeda. Semua waktu task diubah ke UTC.
+
+UPDATE "tasks"
+SET "deadline" = strftime('%Y-%m-%d %H:%M:%f', "deadline") || '+00:00'
+WHERE "deadline" IS NOT NULL AND "deadline" NOT LIKE '%+00:00';
+
+UPDATE "tasks"
+SET "completed_at" = strftime('%Y-%m-%d %H:%M:%f', "completed_at") || '+00:00'
+WHERE "completed_at" IS NOT NULL AND "completed_at" NOT LIKE '%+00:00';
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"sync"
+	"task-management/internal/infra/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type job struct {
+	name string
+	next func(now time.Time) time.Time
+	run  func() error
+}
+
+// Scheduler menjalankan job periodik di background sampai Stop dipanggil.
+type Scheduler struct {
+	jobs []job
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{stop: make(chan struct{})}
+}
+
+// Every mendaftarkan job yang dijalankan setiap interval. Harus dipanggil sebelum Start.
+func (s *Scheduler) Every(name string, interval time.Duration, run func() error) {
+	next := func(now time.Time) time.Time {
+		return now.Add(interval)
+	}
+
+	s.jobs = append(s.jobs, job{name: name, next: next, run: run})
+}
+
+// Hourly mendaftarkan job yang dijalankan setiap awal jam.
+func (s *Scheduler) Hourly(name string, run func() error) {
+	next := func(now time.Time) time.Time {
+		return now.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	s.jobs = append(s.jobs, job{name: name, next: next, run: run})
+}
+
+func (s *Scheduler) Start() {
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+
+	logger.Info("Scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+
+	logger.Info("Scheduler stopped")
+}
+
+func (s *Scheduler) loop(j job) {
+	defer s.wg.Done()
+
+	timer := time.NewTimer(time.Until(j.next(time.Now())))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-timer.C:
+			if err := j.run(); err != nil {
+				logger.Error("scheduled job failed", zap.String("job", j.name), zap.Error(err))
+			}
+
+			timer.Reset(time.Until(j.next(time.Now())))
+		}
+	}
+}
@@ -9,54 +9,99 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-type JWTAdapter struct {
-	secret string
-	ttl    time.Duration
-}
+const (
+	authSubject      = "user-authentication"
+	challengeSubject = "two-factor-challenge"
+	challengeTTL     = 5 * time.Minute
+)
 
-type JWTClaims struct {
-	UserID   uint   `json:"user_id"`
-	Username string `json:"username"`
-	jwt.RegisteredClaims
+type JWTAdapter struct {
+	keys *KeySet
+	ttl  time.Duration
 }
 
-func NewJWTAdapter(secret string, ttl time.Duration) services.JWTService {
+func NewJWTAdapter(keys *KeySet, ttl time.Duration) services.JWTService {
 	return &JWTAdapter{
-		secret: secret,
-		ttl:    ttl,
+		keys: keys,
+		ttl:  ttl,
 	}
 }
 
 // GenerateToken implements services.JWTService.
-func (j *JWTAdapter) GenerateToken(user *domain.User) (string, error) {
-	claims := &JWTClaims{
+func (j *JWTAdapter) GenerateToken(user *domain.User, sessionID string) (string, error) {
+	claims := &domain.JWTClaims{
 		UserID:   user.ID,
 		Username: user.Username,
+		Scopes:   domain.UserScopes(user),
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "task-management-services",
-			Subject:   "user-authentication",
+			Subject:   authSubject,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(j.secret))
+	return j.keys.sign(claims)
+}
+
+// ValidateToken implements services.JWTService.
+func (j *JWTAdapter) ValidateToken(token string) (*domain.JWTClaims, error) {
+	claims, err := j.parse(token)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// challenge token 2FA tidak boleh dipakai sebagai access token
+	if claims.Subject == challengeSubject {
+		return nil, errors.New("invalid token")
 	}
 
-	return signedToken, nil
+	// token yang dibuat sebelum ada scope tetap mendapat akses user biasa
+	if claims.Scopes == nil {
+		claims.Scopes = domain.DefaultUserScopes
+	}
+
+	return claims, nil
 }
 
-// ValidateToken implements services.JWTService.
-func (j *JWTAdapter) ValidateToken(token string) (*domain.JWTClaims, error) {
-	t, err := jwt.ParseWithClaims(token, &domain.JWTClaims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrTokenMalformed
-		}
-		return []byte(j.secret), nil
-	})
+// GenerateChallengeToken implements services.JWTService.
+func (j *JWTAdapter) GenerateChallengeToken(user *domain.User) (string, error) {
+	claims := &domain.JWTClaims{
+		UserID:   user.ID,
+		Username: user.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(challengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "task-management-services",
+			Subject:   challengeSubject,
+		},
+	}
+
+	return j.keys.sign(claims)
+}
+
+// ValidateChallengeToken implements services.JWTService.
+func (j *JWTAdapter) ValidateChallengeToken(token string) (uint, error) {
+	claims, err := j.parse(token)
+	if err != nil {
+		return 0, err
+	}
+
+	if claims.Subject != challengeSubject {
+		return 0, errors.New("invalid challenge token")
+	}
+
+	return claims.UserID, nil
+}
+
+// JWKS implements services.JWTService.
+func (j *JWTAdapter) JWKS() domain.JWKSet {
+	return j.keys.JWKS()
+}
+
+func (j *JWTAdapter) parse(token string) (*domain.JWTClaims, error) {
+	t, err := jwt.ParseWithClaims(token, &domain.JWTClaims{}, j.keys.verificationKey)
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
@@ -0,0 +1,257 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwtKey adalah satu key JWT. Private nil untuk key lama yang hanya dipakai verifikasi.
+type jwtKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private any
+	public  any
+}
+
+// KeySet berisi key penandatangan JWT dan semua key yang masih diterima saat verifikasi.
+// Token HS256 tidak memakai kid, jadi key HMAC disimpan dengan kid kosong.
+type KeySet struct {
+	signing *jwtKey
+	keys    map[string]*jwtKey
+}
+
+// NewHMACKeySet membuat key set HS256 dengan satu shared secret.
+func NewHMACKeySet(secret string) *KeySet {
+	key := newHMACKey(secret)
+
+	return &KeySet{
+		signing: key,
+		keys:    map[string]*jwtKey{"": key},
+	}
+}
+
+// LoadKeySet membaca key dari config. Tanpa key di config, token ditandatangani HS256 dengan secret.
+// Key dengan private key bisa menjadi signing key, key dengan public key saja tetap diterima
+// saat verifikasi supaya token lama masih berlaku selama rotasi.
+func LoadKeySet(cfg config.JWTConfig, secret string) (*KeySet, error) {
+	if len(cfg.Keys) == 0 {
+		return NewHMACKeySet(secret), nil
+	}
+
+	set := &KeySet{keys: map[string]*jwtKey{}}
+
+	for _, keyCfg := range cfg.Keys {
+		if keyCfg.ID == "" {
+			return nil, errors.New("jwt key id is required")
+		}
+
+		if _, exists := set.keys[keyCfg.ID]; exists {
+			return nil, fmt.Errorf("duplicate jwt key id %q", keyCfg.ID)
+		}
+
+		key, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", keyCfg.ID, err)
+		}
+
+		set.keys[keyCfg.ID] = key
+	}
+
+	signing, ok := set.keys[cfg.SigningKey]
+	if !ok {
+		return nil, fmt.Errorf("jwt signing key %q is not configured", cfg.SigningKey)
+	}
+
+	if signing.private == nil {
+		return nil, fmt.Errorf("jwt signing key %q has no private key", cfg.SigningKey)
+	}
+
+	set.signing = signing
+
+	// token HS256 lama (tanpa kid) tetap diterima sampai semuanya kedaluwarsa
+	if cfg.AcceptHS256 && secret != "" {
+		set.keys[""] = newHMACKey(secret)
+	}
+
+	return set, nil
+}
+
+// JWKS mengembalikan public key asimetris yang dipakai verifikasi. Secret HMAC tidak pernah dipublikasikan.
+func (s *KeySet) JWKS() domain.JWKSet {
+	set := domain.JWKSet{Keys: []domain.JWK{}}
+
+	for _, key := range s.keys {
+		if key.id == "" {
+			continue
+		}
+
+		jwk, err := NewJWK(key.id, key.method.Alg(), key.public)
+		if err != nil {
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].Kid < set.Keys[j].Kid
+	})
+
+	return set
+}
+
+func (s *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signing.method, claims)
+	if s.signing.id != "" {
+		token.Header["kid"] = s.signing.id
+	}
+
+	return token.SignedString(s.signing.private)
+}
+
+// verificationKey memilih key berdasarkan kid dan menolak token yang algoritmanya
+// berbeda dengan key tersebut (mencegah algorithm confusion).
+func (s *KeySet) verificationKey(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	if t.Method.Alg() != key.method.Alg() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+
+	return key.public, nil
+}
+
+func newHMACKey(secret string) *jwtKey {
+	return &jwtKey{
+		method:  jwt.SigningMethodHS256,
+		private: []byte(secret),
+		public:  []byte(secret),
+	}
+}
+
+func loadKey(cfg config.JWTKeyConfig) (*jwtKey, error) {
+	key := &jwtKey{id: cfg.ID}
+
+	switch {
+	case cfg.PrivateKeyFile != "":
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := parsePrivateKeyPEM(data)
+		if err != nil {
+			return nil, err
+		}
+
+		key.private = signer
+		key.public = signer.Public()
+	case cfg.PublicKeyFile != "":
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		public, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return nil, err
+		}
+
+		key.public = public
+	default:
+		return nil, errors.New("private_key_file or public_key_file is required")
+	}
+
+	method, err := signingMethodFor(key.public)
+	if err != nil {
+		return nil, err
+	}
+
+	key.method = method
+	return key, nil
+}
+
+func parsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var key any
+	var err error
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
+
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// signingMethodFor menentukan algoritma dari jenis key: RSA -> RS256, EC -> ES256/384/512, Ed25519 -> EdDSA.
+func signingMethodFor(public crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch k := public.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("RSA key must be at least 2048 bits")
+		}
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch k.Curve.Params().Name {
+		case "P-256":
+			return jwt.SigningMethodES256, nil
+		case "P-384":
+			return jwt.SigningMethodES384, nil
+		case "P-521":
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("unsupported curve %s", k.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", public)
+	}
+}
@@ -0,0 +1,228 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeKeyPair menyimpan private key (PKCS8) dan public key (PKIX) ke dir, mengembalikan path-nya.
+func writeKeyPair(t *testing.T, dir, name string, signer crypto.Signer) (string, string) {
+	t.Helper()
+
+	private, err := x509.MarshalPKCS8PrivateKey(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privatePath := filepath.Join(dir, name+".pem")
+	publicPath := filepath.Join(dir, name+".pub.pem")
+
+	if err := os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return privatePath, publicPath
+}
+
+func newTestKeys(t *testing.T) (dir, oldPrivate, oldPublic, newPrivate string) {
+	t.Helper()
+
+	dir = t.TempDir()
+
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPrivate, oldPublic = writeKeyPair(t, dir, "old", oldKey)
+	newPrivate, _ = writeKeyPair(t, dir, "new", newKey)
+	return dir, oldPrivate, oldPublic, newPrivate
+}
+
+func mustKeySet(t *testing.T, cfg config.JWTConfig, secret string) *KeySet {
+	t.Helper()
+
+	set, err := LoadKeySet(cfg, secret)
+	if err != nil {
+		t.Fatalf("LoadKeySet: %v", err)
+	}
+
+	return set
+}
+
+func TestKeyRotationAcceptsPreviousKey(t *testing.T) {
+	_, oldPrivate, oldPublic, newPrivate := newTestKeys(t)
+	user := &domain.User{ID: 7, Username: "alice"}
+
+	before := NewJWTAdapter(mustKeySet(t, config.JWTConfig{
+		SigningKey: "old",
+		Keys:       []config.JWTKeyConfig{{ID: "old", PrivateKeyFile: oldPrivate}},
+	}, ""), time.Hour)
+
+	// setelah rotasi key lama hanya disimpan public key-nya
+	after := NewJWTAdapter(mustKeySet(t, config.JWTConfig{
+		SigningKey: "new",
+		Keys: []config.JWTKeyConfig{
+			{ID: "new", PrivateKeyFile: newPrivate},
+			{ID: "old", PublicKeyFile: oldPublic},
+		},
+	}, ""), time.Hour)
+
+	oldToken, err := before.GenerateToken(user, "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := after.ValidateToken(oldToken)
+	if err != nil || claims.UserID != user.ID || claims.ID != "session-1" {
+		t.Fatalf("token signed with the previous key = %+v, %v", claims, err)
+	}
+
+	newToken, err := after.GenerateToken(user, "session-2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &domain.JWTClaims{})
+	if err != nil || parsed.Header["kid"] != "new" || parsed.Method.Alg() != "ES256" {
+		t.Fatalf("new token header = %v, %v, want kid new and ES256", parsed.Header, err)
+	}
+
+	if _, err := before.ValidateToken(newToken); err == nil {
+		t.Error("token with an unknown kid was accepted")
+	}
+
+	jwks := after.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].Kid != "new" || jwks.Keys[1].Kid != "old" || jwks.Keys[1].Kty != "OKP" {
+		t.Errorf("JWKS = %+v, want both keys", jwks.Keys)
+	}
+}
+
+func TestKeySetHS256Migration(t *testing.T) {
+	_, oldPrivate, _, _ := newTestKeys(t)
+	user := &domain.User{ID: 7, Username: "alice"}
+
+	legacy, err := NewJWTAdapter(NewHMACKeySet("secret"), time.Hour).GenerateToken(user, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.JWTConfig{SigningKey: "old", Keys: []config.JWTKeyConfig{{ID: "old", PrivateKeyFile: oldPrivate}}}
+
+	if _, err := NewJWTAdapter(mustKeySet(t, cfg, "secret"), time.Hour).ValidateToken(legacy); err == nil {
+		t.Error("HS256 token accepted without accept_hs256")
+	}
+
+	cfg.AcceptHS256 = true
+	adapter := NewJWTAdapter(mustKeySet(t, cfg, "secret"), time.Hour)
+
+	if _, err := adapter.ValidateToken(legacy); err != nil {
+		t.Errorf("HS256 token with accept_hs256 = %v", err)
+	}
+
+	if jwks := adapter.JWKS(); len(jwks.Keys) != 1 {
+		t.Errorf("JWKS = %+v, the HMAC secret must not be published", jwks.Keys)
+	}
+}
+
+// token HS256 yang ditandatangani dengan public key sebagai secret HMAC tidak boleh diterima
+func TestKeySetRejectsAlgorithmConfusion(t *testing.T) {
+	_, oldPrivate, oldPublic, _ := newTestKeys(t)
+
+	adapter := NewJWTAdapter(mustKeySet(t, config.JWTConfig{
+		SigningKey: "old",
+		Keys:       []config.JWTKeyConfig{{ID: "old", PrivateKeyFile: oldPrivate}},
+	}, ""), time.Hour)
+
+	publicPEM, err := os.ReadFile(oldPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &domain.JWTClaims{
+		UserID: 1,
+		Scopes: []string{domain.ScopeAdmin},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			Subject:   authSubject,
+		},
+	})
+	forged.Header["kid"] = "old"
+
+	token, err := forged.SignedString(publicPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := adapter.ValidateToken(token); err == nil {
+		t.Fatal("HS256 token with an asymmetric kid was accepted")
+	}
+}
+
+func TestChallengeTokenIsNotAccessToken(t *testing.T) {
+	adapter := NewJWTAdapter(NewHMACKeySet("secret"), time.Hour)
+	user := &domain.User{ID: 3, Username: "bob"}
+
+	challenge, err := adapter.GenerateChallengeToken(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := adapter.ValidateToken(challenge); err == nil {
+		t.Error("challenge token accepted as access token")
+	}
+
+	if id, err := adapter.ValidateChallengeToken(challenge); err != nil || id != user.ID {
+		t.Errorf("ValidateChallengeToken = %d, %v", id, err)
+	}
+
+	access, _ := adapter.GenerateToken(user, "session")
+	if _, err := adapter.ValidateChallengeToken(access); err == nil {
+		t.Error("access token accepted as challenge token")
+	}
+}
+
+func TestLoadKeySetErrors(t *testing.T) {
+	_, oldPrivate, oldPublic, _ := newTestKeys(t)
+
+	tests := map[string]config.JWTConfig{
+		"missing id":     {SigningKey: "a", Keys: []config.JWTKeyConfig{{PrivateKeyFile: oldPrivate}}},
+		"duplicate id":   {SigningKey: "a", Keys: []config.JWTKeyConfig{{ID: "a", PrivateKeyFile: oldPrivate}, {ID: "a", PublicKeyFile: oldPublic}}},
+		"unknown signer": {SigningKey: "b", Keys: []config.JWTKeyConfig{{ID: "a", PrivateKeyFile: oldPrivate}}},
+		"public signer":  {SigningKey: "a", Keys: []config.JWTKeyConfig{{ID: "a", PublicKeyFile: oldPublic}}},
+		"no key file":    {SigningKey: "a", Keys: []config.JWTKeyConfig{{ID: "a"}}},
+	}
+
+	for name, cfg := range tests {
+		if _, err := LoadKeySet(cfg, "secret"); err == nil {
+			t.Errorf("%s: LoadKeySet succeeded", name)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package security
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// LoadPasswordDenylist membaca file denylist, satu password per baris. Baris kosong dan
+// baris yang diawali "#" diabaikan, password disimpan dalam huruf kecil.
+func LoadPasswordDenylist(path string) (map[string]struct{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	denylist := map[string]struct{}{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		denylist[strings.ToLower(line)] = struct{}{}
+	}
+
+	return denylist, scanner.Err()
+}
@@ -0,0 +1,79 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"task-management/internal/applications/ports/services"
+)
+
+// sealedPrefix menandai nilai terenkripsi, nilai tanpa prefix adalah data lama yang belum dienkripsi.
+const sealedPrefix = "v1:"
+
+type secretCipher struct {
+	aead cipher.AEAD
+}
+
+// NewSecretCipher membuat cipher AES-256-GCM dengan key yang diturunkan dari key. Mengganti key
+// membuat secret yang sudah tersimpan tidak bisa dibaca lagi.
+func NewSecretCipher(key string) (services.SecretCipher, error) {
+	if key == "" {
+		return nil, errors.New("secret cipher key is empty")
+	}
+
+	// label memisahkan key ini dari pemakaian secret yang sama untuk HMAC JWT
+	derived := sha256.Sum256([]byte("task-management secret cipher\x00" + key))
+
+	block, err := aes.NewCipher(derived[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &secretCipher{aead: aead}, nil
+}
+
+// Encrypt implements services.SecretCipher.
+func (c *secretCipher) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return sealedPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt implements services.SecretCipher.
+func (c *secretCipher) Decrypt(sealed string) (string, error) {
+	encoded, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return sealed, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < c.aead.NonceSize() {
+		return "", errors.New("sealed secret is too short")
+	}
+
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+
+	plain, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.New("sealed secret cannot be decrypted, was the encryption key changed?")
+	}
+
+	return string(plain), nil
+}
@@ -0,0 +1,52 @@
+package security
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretCipherRoundTrip(t *testing.T) {
+	cipher, err := NewSecretCipher("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+	sealed, err := cipher.Encrypt(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(sealed, secret) || !strings.HasPrefix(sealed, sealedPrefix) || len(sealed) > 255 {
+		t.Fatalf("sealed = %q, want an encrypted value that fits the column", sealed)
+	}
+
+	if again, _ := cipher.Encrypt(secret); again == sealed {
+		t.Error("encrypting twice gave the same value, nonce is not random")
+	}
+
+	if plain, err := cipher.Decrypt(sealed); err != nil || plain != secret {
+		t.Fatalf("Decrypt = %q, %v, want %q", plain, err, secret)
+	}
+
+	// nilai lama tanpa enkripsi dikembalikan apa adanya
+	if plain, err := cipher.Decrypt(secret); err != nil || plain != secret {
+		t.Fatalf("Decrypt legacy = %q, %v", plain, err)
+	}
+
+	other, _ := NewSecretCipher("other key")
+	if _, err := other.Decrypt(sealed); err == nil {
+		t.Error("Decrypt with a different key succeeded")
+	}
+
+	if _, err := cipher.Decrypt(sealed[:len(sealed)-2] + "AA"); err == nil {
+		t.Error("Decrypt of tampered value succeeded")
+	}
+}
+
+func TestSecretCipherRequiresKey(t *testing.T) {
+	if _, err := NewSecretCipher(""); err == nil {
+		t.Fatal("NewSecretCipher with empty key succeeded")
+	}
+}
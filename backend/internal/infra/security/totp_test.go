@@ -0,0 +1,67 @@
+package security
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// secret "12345678901234567890" dari test vector RFC 6238 lampiran B
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPValidateRFCVectors(t *testing.T) {
+	totp := NewTOTPAdapter("Task Management")
+
+	// 6 digit terakhir dari kode 8 digit di RFC 6238
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, code := range vectors {
+		step, ok := totp.Validate(rfcSecret, code, time.Unix(unix, 0))
+		if !ok || step != unix/totpPeriod {
+			t.Errorf("Validate(%s at %d) = %d, %v, want step %d", code, unix, step, ok, unix/totpPeriod)
+		}
+	}
+}
+
+func TestTOTPValidateSkew(t *testing.T) {
+	totp := NewTOTPAdapter("Task Management")
+	at := time.Unix(59, 0)
+
+	// kode dari step sebelumnya masih diterima, dua step sebelumnya tidak
+	if _, ok := totp.Validate(rfcSecret, "287082", at.Add(totpPeriod*time.Second)); !ok {
+		t.Error("code from the previous step was rejected")
+	}
+
+	if _, ok := totp.Validate(rfcSecret, "287082", at.Add(2*totpPeriod*time.Second)); ok {
+		t.Error("code from two steps ago was accepted")
+	}
+
+	for _, code := range []string{"", "28708", "2870820", "abcdef"} {
+		if _, ok := totp.Validate(rfcSecret, code, at); ok {
+			t.Errorf("Validate(%q) accepted", code)
+		}
+	}
+}
+
+func TestTOTPSecretAndURI(t *testing.T) {
+	totp := NewTOTPAdapter("Task Management")
+
+	secret, err := totp.GenerateSecret()
+	if err != nil || len(secret) != 32 {
+		t.Fatalf("GenerateSecret = %q, %v, want 32 base32 characters", secret, err)
+	}
+
+	uri, err := url.Parse(totp.URI(secret, "alice"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Query().Get("secret") != secret || uri.Query().Get("issuer") != "Task Management" {
+		t.Errorf("URI = %s", uri)
+	}
+}
@@ -0,0 +1,225 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/infra/db"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// testApp menjalankan seluruh route API di atas SQLite in-memory.
+type testApp struct {
+	t      *testing.T
+	server *AppServer
+}
+
+func newTestApp(t *testing.T) *testApp {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	dbCfg := config.DatabaseConfig{Driver: "sqlite", Name: ":memory:"}
+
+	database, err := db.Connect(dbCfg)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(func() { _ = database.Close() })
+
+	migrator, err := db.NewMigrator(database.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := migrator.Up(0); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	cfg := &config.AppConfig{
+		Database:  dbCfg,
+		Secret:    "test-secret",
+		RateLimit: config.RateLimitConfig{Disabled: true},
+		Storage:   config.StorageConfig{Dir: t.TempDir()},
+	}
+
+	server, err := InitServer(cfg, database.DB)
+	if err != nil {
+		t.Fatalf("InitServer: %v", err)
+	}
+
+	return &testApp{t: t, server: server}
+}
+
+// do mengirim request JSON dan mengembalikan status serta body.
+func (a *testApp) do(method, path, token string, body any) (int, map[string]any) {
+	a.t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			a.t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	a.server.Gin.ServeHTTP(rec, req)
+
+	var decoded map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &decoded)
+
+	return rec.Code, decoded
+}
+
+func (a *testApp) register(username string, role domain.Role) uint {
+	a.t.Helper()
+
+	status, body := a.do(http.MethodPost, "/api/v1/auth/register", "", map[string]string{
+		"name": username, "username": username, "password": "long password",
+	})
+	if status != http.StatusCreated {
+		a.t.Fatalf("register %s: %d %v", username, status, body)
+	}
+
+	var user domain.User
+	if err := a.server.DB.Where("username = ?", username).First(&user).Error; err != nil {
+		a.t.Fatal(err)
+	}
+
+	if role != domain.RoleUser {
+		if err := a.server.DB.Model(&user).Update("role", role).Error; err != nil {
+			a.t.Fatal(err)
+		}
+	}
+
+	return user.ID
+}
+
+func (a *testApp) login(username string) (int, string) {
+	a.t.Helper()
+
+	status, body := a.do(http.MethodPost, "/api/v1/auth/login", "", map[string]string{
+		"username": username, "password": "long password",
+	})
+
+	data, _ := body["data"].(map[string]any)
+	token, _ := data["token"].(string)
+
+	return status, token
+}
+
+func (a *testApp) mustLogin(username string) string {
+	a.t.Helper()
+
+	status, token := a.login(username)
+	if status != http.StatusOK || token == "" {
+		a.t.Fatalf("login %s: status %d", username, status)
+	}
+
+	return token
+}
+
+func TestAdminRoutesRequireAdmin(t *testing.T) {
+	app := newTestApp(t)
+	app.register("root", domain.RoleAdmin)
+	bobID := app.register("bob", domain.RoleUser)
+
+	root := app.mustLogin("root")
+	bob := app.mustLogin("bob")
+
+	if status, body := app.do(http.MethodGet, "/api/v1/admin/users", root, nil); status != http.StatusOK {
+		t.Fatalf("admin list users: %d %v", status, body)
+	}
+
+	for _, route := range []struct{ method, path string }{
+		{http.MethodGet, "/api/v1/admin/users"},
+		{http.MethodGet, fmt.Sprintf("/api/v1/admin/users/%d", bobID)},
+		{http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/deactivate", bobID)},
+		{http.MethodDelete, fmt.Sprintf("/api/v1/admin/users/%d", bobID)},
+	} {
+		if status, body := app.do(route.method, route.path, bob, nil); status != http.StatusForbidden {
+			t.Errorf("%s %s as non-admin: %d %v, want 403", route.method, route.path, status, body)
+		}
+	}
+
+	if status, _ := app.do(http.MethodGet, "/api/v1/admin/users", "", nil); status != http.StatusUnauthorized {
+		t.Errorf("admin list users without token: %d, want 401", status)
+	}
+
+	// hak admin yang dicabut langsung berlaku untuk token yang sudah terbit
+	if err := app.server.DB.Model(&domain.User{}).Where("username = ?", "root").Update("role", domain.RoleUser).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if status, _ := app.do(http.MethodGet, "/api/v1/admin/users", root, nil); status != http.StatusForbidden {
+		t.Errorf("demoted admin: %d, want 403", status)
+	}
+}
+
+func TestAdminDeactivateBlocksLoginAndTokens(t *testing.T) {
+	app := newTestApp(t)
+	rootID := app.register("root", domain.RoleAdmin)
+	aliceID := app.register("alice", domain.RoleUser)
+
+	root := app.mustLogin("root")
+	alice := app.mustLogin("alice")
+
+	status, body := app.do(http.MethodPost, "/api/v1/profile/tokens", alice, map[string]any{
+		"name": "cron", "scopes": []string{domain.ScopeTasksRead},
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("create api token: %d %v", status, body)
+	}
+
+	pat := body["data"].(map[string]any)["token"].(string)
+
+	for _, token := range []string{alice, pat} {
+		if status, _ := app.do(http.MethodGet, "/api/v1/tasks/", token, nil); status != http.StatusOK {
+			t.Fatalf("list tasks before deactivation: %d", status)
+		}
+	}
+
+	if status, body := app.do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/deactivate", aliceID), root, nil); status != http.StatusOK {
+		t.Fatalf("deactivate: %d %v", status, body)
+	}
+
+	if status, _ := app.login("alice"); status != http.StatusForbidden {
+		t.Errorf("login after deactivation: %d, want 403", status)
+	}
+
+	for name, token := range map[string]string{"login token": alice, "api token": pat} {
+		if status, _ := app.do(http.MethodGet, "/api/v1/tasks/", token, nil); status != http.StatusUnauthorized {
+			t.Errorf("%s after deactivation: %d, want 401", name, status)
+		}
+	}
+
+	// admin tidak bisa menonaktifkan dirinya sendiri
+	if status, _ := app.do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/deactivate", rootID), root, nil); status != http.StatusBadRequest {
+		t.Errorf("deactivate self: %d, want 400", status)
+	}
+
+	if status, body := app.do(http.MethodPost, fmt.Sprintf("/api/v1/admin/users/%d/reactivate", aliceID), root, nil); status != http.StatusOK {
+		t.Fatalf("reactivate: %d %v", status, body)
+	}
+
+	// session lama sudah dicabut saat dinonaktifkan, jadi harus login ulang
+	if status, _ := app.do(http.MethodGet, "/api/v1/tasks/", alice, nil); status != http.StatusUnauthorized {
+		t.Errorf("old login token after reactivation: %d, want 401", status)
+	}
+
+	if status, _ := app.login("alice"); status != http.StatusOK {
+		t.Errorf("login after reactivation: %d, want 200", status)
+	}
+}
@@ -2,39 +2,203 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"task-management/internal/applications/ports/repository"
+	servicePorts "task-management/internal/applications/ports/services"
 	"task-management/internal/applications/services"
 	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/infra/adapter/blob"
+	"task-management/internal/infra/adapter/email"
 	"task-management/internal/infra/adapter/http/handler"
+	"task-management/internal/infra/adapter/http/middleware"
 	"task-management/internal/infra/adapter/http/router"
+	"task-management/internal/infra/adapter/imaging"
+	"task-management/internal/infra/adapter/oidc"
 	"task-management/internal/infra/adapter/storages"
+	"task-management/internal/infra/adapter/storages/memory"
+	redisstore "task-management/internal/infra/adapter/storages/redis"
 	"task-management/internal/infra/logger"
+	"task-management/internal/infra/scheduler"
 	"task-management/internal/infra/security"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	goredis "github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
+// tokenTTL adalah umur access token sekaligus umur session login.
+const tokenTTL = 24 * time.Hour
+
 type AppServer struct {
-	DB     *gorm.DB
-	Config *config.AppConfig
-	Gin    *gin.Engine
+	DB        *gorm.DB
+	Config    *config.AppConfig
+	Gin       *gin.Engine
+	Scheduler *scheduler.Scheduler
 }
 
-func InitServer(cf *config.AppConfig, db *gorm.DB) *AppServer {
+func InitServer(cf *config.AppConfig, db *gorm.DB) (*AppServer, error) {
+	engine, err := newEngine(cf.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	taskRepo, userRepo, unitOfWork, err := newTaskUserRepositories(cf.Repository.Store, cf.Database, db)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtKeys, err := security.LoadKeySet(cf.JWT, cf.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtService := security.NewJWTAdapter(jwtKeys, tokenTTL)
+	sessionRepo := storages.NewSessionRepository(db)
+	sessionService := services.NewSessionService(sessionRepo, jwtService, tokenTTL)
+	sessionHandler := handler.NewSessionHandler(sessionService)
+	jwksHandler := handler.NewJWKSHandler(jwtService)
+	loginAttempts := newLoginAttemptStore(cf.Login.Store, db)
+	loginAudit := storages.NewLoginAuditRepository(db)
+	loginPolicy := newLoginPolicy(cf.Login)
+	recoveryCodeRepo := storages.NewRecoveryCodeRepository(db)
+	totpCipher, err := security.NewSecretCipher(stringOrDefault(cf.TwoFactor.EncryptionKey, cf.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("two_factor.encryption_key or secret is required: %w", err)
+	}
+
+	twoFactorService := services.NewTwoFactorService(userRepo, recoveryCodeRepo, security.NewTOTPAdapter("Task Management"), totpCipher)
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService)
+	passwordPolicy := newPasswordPolicy(cf.Password)
+	notifier := newNotifier(cf.SMTP)
+	blobStorage, err := newBlobStorage(cf)
+	if err != nil {
+		return nil, err
+	}
+
+	emailVerificationRepo := storages.NewEmailVerificationTokenRepository(db)
+	profileService := services.NewProfileService(userRepo, emailVerificationRepo, notifier, blobStorage, imaging.NewResizer(),
+		minutesOrDefault(cf.Profile.VerifyTTL, 24*60), cf.Profile.VerifyURL)
+	profileHandler := handler.NewProfileHandler(profileService)
+	authService := services.NewAuthService(userRepo, jwtService, sessionService, twoFactorService, passwordPolicy, loginAttempts, loginAudit,
+		loginPolicy, profileService)
+	authHandler := handler.NewAuthHandler(authService)
+	bootstrapService := services.NewBootstrapService(userRepo, unitOfWork, passwordPolicy, cf.Bootstrap.Token)
+	if err := checkDefaultCredentials(bootstrapService, cf.Server); err != nil {
+		return nil, err
+	}
+
+	setupHandler := handler.NewSetupHandler(bootstrapService)
+	notificationRepo := storages.NewNotificationRepository(db)
+	preferenceRepo := storages.NewNotificationPreferenceRepository(db)
+	passwordResetRepo := storages.NewPasswordResetTokenRepository(db)
+	passwordService := services.NewPasswordService(userRepo, passwordResetRepo, sessionService, notifier, passwordPolicy,
+		minutesOrDefault(cf.Password.ResetTTL, 30), cf.Password.ResetURL)
+	passwordHandler := handler.NewPasswordHandler(passwordService)
+	notificationService := services.NewNotificationService(notificationRepo, preferenceRepo, taskRepo, userRepo, notifier)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	digestService := services.NewDigestService(taskRepo, preferenceRepo, userRepo, notifier, cf.Notification.DigestHour)
+	taskService := services.NewTaskService(taskRepo, unitOfWork, notificationService)
+	taskHandler := handler.NewTaskHandler(taskService)
+	calendarFeedRepo := storages.NewCalendarFeedRepository(db)
+	calendarService := services.NewCalendarService(calendarFeedRepo, taskRepo, userRepo)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	importService := services.NewImportService(unitOfWork, userRepo, notificationService)
+	importHandler := handler.NewImportHandler(importService)
+	apiTokenRepo := storages.NewAPITokenRepository(db)
+	apiTokenService := services.NewAPITokenService(apiTokenRepo, userRepo)
+	apiTokenHandler := handler.NewAPITokenHandler(apiTokenService)
+	deletionPolicy, err := newDeletionPolicy(cf.Profile.DeletionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	identityRepo := storages.NewUserIdentityRepository(db)
+	accountService := services.NewAccountService(userRepo, taskRepo, notificationRepo, preferenceRepo, sessionRepo, apiTokenRepo,
+		identityRepo, loginAudit, blobStorage, unitOfWork, deletionPolicy)
+	accountHandler := handler.NewAccountHandler(accountService)
+	adminService := services.NewAdminService(userRepo, taskRepo, sessionService, passwordService, accountService)
+	adminHandler := handler.NewAdminHandler(adminService)
+
+	// Setup router
+	handlers := router.Handlers{
+		Auth:         authHandler,
+		Task:         taskHandler,
+		Notification: notificationHandler,
+		Calendar:     calendarHandler,
+		Import:       importHandler,
+		APIToken:     apiTokenHandler,
+		TwoFactor:    twoFactorHandler,
+		Password:     passwordHandler,
+		JWKS:         jwksHandler,
+		Session:      sessionHandler,
+		Admin:        adminHandler,
+		Setup:        setupHandler,
+		Profile:      profileHandler,
+		Account:      accountHandler,
+	}
+
+	var oidcService servicePorts.OIDCService
+	if cf.OIDC.Issuer != "" {
+		oidcStateRepo := storages.NewOIDCLoginStateRepository(db)
+		oidcService = services.NewOIDCService(oidc.NewProvider(cf.OIDC), userRepo, identityRepo, oidcStateRepo, sessionService, unitOfWork)
+		handlers.OIDC = handler.NewOIDCHandler(oidcService, cf.OIDC.SuccessRedirect)
+	}
+	router.SetupRoutes(engine, handlers, jwtService, apiTokenService, authService, newRateLimits(cf.RateLimit, cf.Redis))
+
+	// Background jobs
+	jobs := scheduler.New()
+	deadlineWindow := hoursOrDefault(cf.Notification.DeadlineWindow, 24)
+	jobs.Every("deadline-reminder", minutesOrDefault(cf.Notification.ReminderInterval, 15), func() error {
+		return notificationService.NotifyUpcomingDeadlines(deadlineWindow)
+	})
+	jobs.Every("login-attempt-prune", time.Hour, func() error {
+		return loginAttempts.Prune(time.Now().Add(-loginPolicy.Window - loginPolicy.Lockout))
+	})
+	jobs.Every("session-prune", time.Hour, sessionService.PruneExpired)
+	if oidcService != nil {
+		jobs.Every("oidc-state-prune", time.Hour, oidcService.PruneLoginStates)
+	}
+	// jam pengiriman digest mengikuti zona waktu tiap user, jadi job berjalan setiap jam
+	jobs.Hourly("digest", func() error {
+		now := time.Now()
+
+		if err := digestService.SendDigests(domain.DigestDaily, now); err != nil {
+			return err
+		}
+
+		return digestService.SendDigests(domain.DigestWeekly, now)
+	})
+
+	return &AppServer{
+		DB:        db,
+		Config:    cf,
+		Gin:       engine,
+		Scheduler: jobs,
+	}, nil
+}
+
+// newEngine membuat gin engine dengan CORS. Header X-Forwarded-For hanya dipercaya dari
+// TrustedProxies, tanpa itu client bisa memalsukan IP untuk lolos dari lockout dan rate limit per IP.
+func newEngine(cfg config.ServerConfig) (*gin.Engine, error) {
 	engine := gin.Default()
 
+	if err := engine.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid server.trusted_proxies: %w", err)
+	}
+
 	// Enable CORS
 	engine.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Accept, Authorization, X-Timezone")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -44,22 +208,206 @@ func InitServer(cf *config.AppConfig, db *gorm.DB) *AppServer {
 		c.Next()
 	})
 
-	userRepo := storages.NewUserRepository(db)
-	jwtService := security.NewJWTAdapter(cf.Secret, 24*time.Hour)
-	authService := services.NewAuthService(userRepo, jwtService)
-	authHandler := handler.NewAuthHandler(authService)
-	taskRepo := storages.NewTaskRepository(db)
-	taskService := services.NewTaskService(taskRepo)
-	taskHandler := handler.NewTaskHandler(taskService)
+	return engine, nil
+}
 
-	// Setup router
-	router.SetupRoutes(engine, authHandler, taskHandler, jwtService)
+// newNotifier memakai SMTP jika host dikonfigurasi, selain itu email hanya dicatat di log.
+func newNotifier(cfg config.SMTPConfig) servicePorts.Notifier {
+	if cfg.Host == "" {
+		logger.Warn("SMTP host is not configured, emails will only be logged")
+		return email.NewLogNotifier()
+	}
 
-	return &AppServer{
-		DB:     db,
-		Config: cf,
-		Gin:    engine,
+	notifier, err := email.NewSMTPNotifier(cfg)
+	if err != nil {
+		logger.Error("failed to init SMTP notifier, emails will only be logged", zap.Error(err))
+		return email.NewLogNotifier()
+	}
+
+	return notifier
+}
+
+// newRateLimits membuat middleware rate limit untuk route API dan /auth.
+func newRateLimits(cfg config.RateLimitConfig, redisCfg config.RedisConfig) router.RateLimits {
+	if cfg.Disabled {
+		return router.RateLimits{}
+	}
+
+	store := newRateLimitStore(cfg.Store, redisCfg)
+
+	return router.RateLimits{
+		API:  middleware.RateLimit(store, "api", newRateLimitRule(cfg.API, 120, 60)),
+		Auth: middleware.RateLimit(store, "auth", newRateLimitRule(cfg.Auth, 10, 60)),
+	}
+}
+
+// newRateLimitStore memakai Redis jika dipilih dan bisa dihubungi, selain itu in-memory.
+func newRateLimitStore(store string, cfg config.RedisConfig) repository.RateLimitStore {
+	if store != "redis" {
+		return memory.NewRateLimitStore()
+	}
+
+	client := goredis.NewClient(&goredis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		logger.Error("failed to connect to redis, rate limit falls back to memory", zap.String("addr", cfg.Addr), zap.Error(err))
+		client.Close()
+		return memory.NewRateLimitStore()
+	}
+
+	return redisstore.NewRateLimitStore(client, "ratelimit:")
+}
+
+func newRateLimitRule(cfg config.RateLimitRule, limit, period int) domain.RateLimit {
+	return domain.RateLimit{
+		Limit:  intOrDefault(cfg.Limit, limit),
+		Period: secondsOrDefault(cfg.Period, period),
+	}
+}
+
+// newPasswordPolicy membuat password policy dari config. Jika file denylist gagal dibaca,
+// server tetap jalan tanpa denylist dan error dicatat di log.
+func newPasswordPolicy(cfg config.PasswordConfig) domain.PasswordPolicy {
+	policy := domain.PasswordPolicy{
+		MinLength:     intOrDefault(cfg.MinLength, 8),
+		RequireUpper:  cfg.RequireUpper,
+		RequireLower:  cfg.RequireLower,
+		RequireDigit:  cfg.RequireDigit,
+		RequireSymbol: cfg.RequireSymbol,
+	}
+
+	if cfg.DenylistFile != "" {
+		denylist, err := security.LoadPasswordDenylist(cfg.DenylistFile)
+		if err != nil {
+			logger.Error("failed to load password denylist", zap.String("file", cfg.DenylistFile), zap.Error(err))
+		} else {
+			policy.Denylist = denylist
+		}
+	}
+
+	return policy
+}
+
+// newTaskUserRepositories membuat repository task dan user beserta unit of work-nya, semuanya
+// memakai store yang sama supaya task ikut terhapus saat user dihapus.
+//
+// Store memory hanya boleh dipakai dengan database SQLite ":memory:". Data lain milik user
+// (session, token, calendar feed, identitas SSO, recovery code) disimpan di database berdasarkan
+// user_id, jika database tetap ada setelah restart, user baru dengan ID yang sama akan mewarisinya.
+func newTaskUserRepositories(store string, dbCfg config.DatabaseConfig, db *gorm.DB) (repository.TaskRepository, repository.UserRepository, repository.UnitOfWork, error) {
+	if store == "memory" {
+		if dbCfg.Driver != "sqlite" || dbCfg.Name != ":memory:" {
+			return nil, nil, nil, errors.New(`repository.store "memory" requires database.driver "sqlite" with database.name ":memory:"`)
+		}
+
+		logger.Warn("tasks and users are stored in memory and will be lost on restart")
+
+		tasks := memory.NewTaskRepository()
+		users := memory.NewUserRepository(tasks)
+		return tasks, users, memory.NewUnitOfWork(tasks, users, storages.NewUnitOfWork(db)), nil
+	}
+
+	return storages.NewTaskRepository(db), storages.NewUserRepository(db), storages.NewUnitOfWork(db), nil
+}
+
+// newBlobStorage menyimpan avatar di memory jika user disimpan di memory, file di disk
+// tidak boleh hidup lebih lama dari user pemiliknya.
+func newBlobStorage(cf *config.AppConfig) (servicePorts.BlobStorage, error) {
+	if cf.Repository.Store == "memory" {
+		return blob.NewMemoryStorage(), nil
+	}
+
+	return blob.NewLocalStorage(stringOrDefault(cf.Storage.Dir, "data"))
+}
+
+func newLoginAttemptStore(store string, db *gorm.DB) repository.LoginAttemptStore {
+	if store == "db" {
+		return storages.NewLoginAttemptStore(db)
+	}
+
+	return memory.NewLoginAttemptStore()
+}
+
+func newLoginPolicy(cfg config.LoginProtectionConfig) domain.LoginPolicy {
+	return domain.LoginPolicy{
+		MaxAttempts:   intOrDefault(cfg.MaxAttempts, 10),
+		IPMaxAttempts: intOrDefault(cfg.IPMaxAttempts, 50),
+		FreeAttempts:  intOrDefault(cfg.FreeAttempts, 3),
+		Window:        minutesOrDefault(cfg.Window, 15),
+		Lockout:       minutesOrDefault(cfg.Lockout, 15),
+		BaseDelay:     secondsOrDefault(cfg.BaseDelay, 1),
+		MaxDelay:      secondsOrDefault(cfg.MaxDelay, 30),
+	}
+}
+
+// checkDefaultCredentials menolak start di mode production selama masih ada akun dengan
+// password bawaan, di mode lain hanya memberi peringatan di log.
+func checkDefaultCredentials(bootstrap servicePorts.BootstrapService, cf config.ServerConfig) error {
+	usernames, err := bootstrap.DefaultCredentialUsers()
+	if err != nil {
+		return err
+	}
+
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	if cf.IsProduction() {
+		return fmt.Errorf("refusing to start in production mode: accounts %v still use the default password, change or delete them first", usernames)
+	}
+
+	logger.Warn("accounts still use the default password, change or delete them before running in production",
+		zap.Strings("usernames", usernames))
+
+	return nil
+}
+
+func newDeletionPolicy(name string) (domain.DeletionPolicy, error) {
+	policy := domain.DeletionPolicy(stringOrDefault(name, string(domain.DeletionDelete)))
+	if !policy.IsValid() {
+		return "", fmt.Errorf("invalid profile.deletion_policy %q, use delete or anonymize", name)
+	}
+
+	return policy, nil
+}
+
+func stringOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func intOrDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func secondsOrDefault(seconds, fallback int) time.Duration {
+	return time.Duration(intOrDefault(seconds, fallback)) * time.Second
+}
+
+func hoursOrDefault(hours, fallback int) time.Duration {
+	if hours <= 0 {
+		hours = fallback
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func minutesOrDefault(minutes, fallback int) time.Duration {
+	if minutes <= 0 {
+		minutes = fallback
 	}
+	return time.Duration(minutes) * time.Minute
 }
 
 func StartServer(app *AppServer) *http.Server {
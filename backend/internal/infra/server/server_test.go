@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task-management/internal/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func clientIP(t *testing.T, cfg config.ServerConfig, remoteAddr string) string {
+	t.Helper()
+
+	engine, err := newEngine(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine.GET("/ip", func(c *gin.Context) {
+		c.String(http.StatusOK, c.ClientIP())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	return rec.Body.String()
+}
+
+func TestNewEngineIgnoresForwardedForByDefault(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	if ip := clientIP(t, config.ServerConfig{}, "198.51.100.1:4000"); ip != "198.51.100.1" {
+		t.Fatalf("ClientIP = %q, want connection address", ip)
+	}
+}
+
+func TestNewEngineTrustsConfiguredProxy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.ServerConfig{TrustedProxies: []string{"10.0.0.0/8"}}
+
+	if ip := clientIP(t, cfg, "10.1.2.3:4000"); ip != "203.0.113.7" {
+		t.Fatalf("ClientIP via trusted proxy = %q, want forwarded address", ip)
+	}
+
+	if ip := clientIP(t, cfg, "198.51.100.1:4000"); ip != "198.51.100.1" {
+		t.Fatalf("ClientIP via untrusted peer = %q, want connection address", ip)
+	}
+}
+
+func TestNewEngineRejectsInvalidProxy(t *testing.T) {
+	if _, err := newEngine(config.ServerConfig{TrustedProxies: []string{"not-an-ip"}}); err == nil {
+		t.Fatal("expected error for invalid trusted proxy")
+	}
+}
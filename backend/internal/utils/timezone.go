@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var locations sync.Map
+
+// LoadLocation memuat zona waktu IANA dan menyimpannya di cache. Nama kosong berarti UTC,
+// "Local" ditolak karena hasilnya bergantung pada zona waktu server.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" || name == "UTC" {
+		return time.UTC, nil
+	}
+
+	if name == "Local" {
+		return nil, errors.New("unknown time zone Local")
+	}
+
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(name, loc)
+	return loc, nil
+}
+
+// LocationOrUTC sama dengan LoadLocation, tetapi mengembalikan UTC jika nama tidak valid.
+func LocationOrUTC(name string) *time.Location {
+	loc, err := LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}